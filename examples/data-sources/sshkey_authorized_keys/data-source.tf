@@ -0,0 +1,41 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "backup" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "user_ca" {
+  type = "ed25519"
+}
+
+data "sshkey_authorized_keys" "bastion" {
+  keys = [
+    # Read-only rsync access from the backup network
+    {
+      public_key  = sshkey_pair.backup.public_key
+      restrict    = true
+      command     = "/usr/bin/rrsync -ro /srv/backup"
+      from        = ["192.0.2.0/24"]
+      expiry_time = "2030-01-01T00:00:00Z"
+    },
+    # Trust user certificates for the ops principal
+    {
+      public_key     = sshkey_pair.user_ca.public_key
+      cert_authority = true
+      principals     = ["ops"]
+    },
+  ]
+}
+
+output "authorized_keys" {
+  value = data.sshkey_authorized_keys.bastion.authorized_keys
+}
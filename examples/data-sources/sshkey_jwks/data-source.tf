@@ -0,0 +1,30 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "current" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "next" {
+  type  = "ecdsa"
+  curve = "P256"
+}
+
+data "sshkey_jwks" "example" {
+  public_keys = [
+    sshkey_pair.current.public_key,
+    sshkey_pair.next.public_key,
+  ]
+}
+
+output "jwks" {
+  value = data.sshkey_jwks.example.json
+}
@@ -0,0 +1,42 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "web" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "host_ca" {
+  type = "ed25519"
+}
+
+data "sshkey_known_hosts" "example" {
+  hosts = [
+    {
+      hostnames  = ["web.example.com", "192.0.2.10"]
+      public_key = sshkey_pair.web.public_key
+    },
+    {
+      hostnames  = ["git.example.com"]
+      port       = 2222
+      public_key = sshkey_pair.web.public_key
+    },
+    # Trust all host certificates signed by the CA
+    {
+      hostnames  = ["*.example.com"]
+      public_key = sshkey_pair.host_ca.public_key
+      marker     = "cert-authority"
+    },
+  ]
+}
+
+output "known_hosts" {
+  value = data.sshkey_known_hosts.example.known_hosts
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+variable "private_key" {
+  type      = string
+  sensitive = true
+}
+
+data "sshkey_private_key" "example" {
+  private_key = var.private_key
+}
+
+output "public_key" {
+  value = data.sshkey_private_key.example.public_key
+}
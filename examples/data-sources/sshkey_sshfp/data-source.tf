@@ -0,0 +1,36 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "web_rsa" {
+  type = "rsa"
+}
+
+resource "sshkey_pair" "web_ed25519" {
+  type = "ed25519"
+}
+
+data "sshkey_sshfp" "example" {
+  hostname = "web.example.com."
+  public_keys = [
+    sshkey_pair.web_rsa.public_key,
+    sshkey_pair.web_ed25519.public_key,
+  ]
+}
+
+# Records for DNS provider resources, e.g. one per element
+output "sshfp_rdata" {
+  value = data.sshkey_sshfp.example.records[*].rdata
+}
+
+# Records ready to be included in a BIND zone file
+output "zone" {
+  value = data.sshkey_sshfp.example.zone
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_version = ">= 1.11.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+    vault = {
+      source = "hashicorp/vault"
+    }
+  }
+}
+
+ephemeral "sshkey_pair" "ci" {
+  type    = "ed25519"
+  comment = "ci@example.com"
+}
+
+# The private key is only passed to the write-only attribute and never
+# persisted in the state file.
+resource "vault_kv_secret_v2" "ci" {
+  mount = "secret"
+  name  = "ci/ssh"
+  data_json_wo = jsonencode({
+    private_key = ephemeral.sshkey_pair.ci.private_key
+    public_key  = ephemeral.sshkey_pair.ci.public_key
+  })
+  data_json_wo_version = 1
+}
@@ -25,6 +25,7 @@ resource "sshkey_pair" "ed25519" {
 
 resource "sshkey_pair" "ecdsa" {
   type    = "ecdsa"
+  curve   = "P256"
   comment = "admin@example.com"
 }
 
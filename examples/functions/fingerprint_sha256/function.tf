@@ -0,0 +1,14 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+output "fingerprint" {
+  value = provider::sshkey::fingerprint_sha256("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBEmI0+9DDo4wb2dvm25yqFZDDSbo0ksF2nU8v9VxXKJ jane@example.com")
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+variable "private_key" {
+  type      = string
+  sensitive = true
+}
+
+output "public_key" {
+  value = nonsensitive(provider::sshkey::public_key_from_private(var.private_key, null))
+}
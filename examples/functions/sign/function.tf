@@ -0,0 +1,24 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "signer" {
+  type = "ed25519"
+}
+
+locals {
+  config = jsonencode({
+    hostname = "web01.example.com"
+  })
+}
+
+output "config_signature" {
+  value = nonsensitive(provider::sshkey::sign(sshkey_pair.signer.private_key, null, "file", local.config))
+}
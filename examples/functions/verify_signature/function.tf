@@ -0,0 +1,29 @@
+terraform {
+  required_version = ">= 1.8.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+variable "config" {
+  type = string
+}
+
+variable "config_signature" {
+  type = string
+}
+
+output "valid" {
+  value = provider::sshkey::verify_signature(
+    "deploy@example.com namespaces=\"file\",valid-before=\"20301231Z\" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBEmI0+9DDo4wb2dvm25yqFZDDSbo0ksF2nU8v9VxXKJ",
+    "deploy@example.com",
+    "file",
+    var.config,
+    var.config_signature,
+    null,
+  )
+}
@@ -9,5 +9,10 @@ terraform {
   }
 }
 provider "sshkey" {
-  # example configuration here
+  default_type        = "ecdsa"
+  default_ecdsa_curve = "P256"
+  default_kdf_rounds  = 64
+
+  # Values known to Terraform are interpolated before the template is rendered
+  default_comment = "${terraform.workspace}-{{.Type}}@{{.Hostname}}"
 }
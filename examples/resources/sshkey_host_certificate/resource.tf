@@ -0,0 +1,27 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "host" {
+  type = "ed25519"
+}
+
+resource "sshkey_host_certificate" "example" {
+  ca_private_key        = sshkey_pair.ca.private_key
+  public_key            = sshkey_pair.host.public_key
+  key_id                = "bastion"
+  principals            = ["bastion.example.com", "10.0.0.1"]
+  validity_period_hours = 720
+  early_renewal_hours   = 168
+}
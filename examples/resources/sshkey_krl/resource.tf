@@ -0,0 +1,45 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "~>2.0"
+    }
+  }
+}
+
+resource "sshkey_pair" "user_ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "leaked" {
+  type = "ed25519"
+}
+
+resource "sshkey_krl" "example" {
+  comment = "revoked user keys"
+
+  public_keys = [sshkey_pair.leaked.public_key]
+
+  ca_public_key = sshkey_pair.user_ca.public_key
+  serials       = [42]
+  key_ids       = ["jane@example.com"]
+
+  serial_ranges = [
+    {
+      min = 1000
+      max = 1999
+    },
+  ]
+}
+
+# RevokedKeys /etc/ssh/revoked_keys
+resource "local_file" "revoked_keys" {
+  filename       = "revoked_keys"
+  content_base64 = sshkey_krl.example.krl_base64
+}
@@ -0,0 +1,4 @@
+import {
+  to = sshkey_pair.example
+  id = file("~/.ssh/id_ed25519")
+}
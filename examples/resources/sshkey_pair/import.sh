@@ -0,0 +1,7 @@
+# Import a private key from a file. Encrypted keys require the passphrase in
+# SSHKEY_IMPORT_PASSPHRASE to read the key, and in passphrase or passphrase_wo
+# of the configuration, as it is not stored in state on import.
+terraform import sshkey_pair.example file:/home/user/.ssh/id_ed25519
+
+# Import a private key from an environment variable.
+terraform import sshkey_pair.example env:LEGACY_PRIVATE_KEY
@@ -12,3 +12,21 @@ terraform {
 resource "sshkey_pair" "example" {
   type = "rsa"
 }
+
+# Replaced by the first plan after 90 days
+resource "sshkey_pair" "rotating" {
+  type          = "ed25519"
+  rotation_days = 90
+}
+
+variable "test_seed" {
+  type      = string
+  sensitive = true
+}
+
+# The same seed and label always yield the same key
+resource "sshkey_pair" "derived" {
+  type       = "ed25519"
+  seed       = var.test_seed
+  seed_label = "web"
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    sshkey = {
+      source  = "jlec.de/dev/sshkey"
+      version = ">=0.1"
+    }
+  }
+}
+
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "user" {
+  type = "ed25519"
+}
+
+resource "sshkey_user_certificate" "example" {
+  ca_private_key = sshkey_pair.ca.private_key
+  public_key     = sshkey_pair.user.public_key
+  key_id         = "jane@example.com"
+  principals     = ["jane", "deploy"]
+  valid_before   = "2030-01-01T00:00:00Z"
+
+  critical_options = {
+    "source-address" = "10.0.0.0/8"
+  }
+
+  extensions = ["permit-pty", "permit-port-forwarding"]
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"slices"
+	"strings"
+)
+
+// Security key types. Their private keys live on FIDO hardware, so they can
+// be parsed and certified, but not generated.
+const (
+	ED25519SK KeyType = "ed25519-sk"
+	ECDSASK   KeyType = "ecdsa-sk"
+)
+
+// KeyAlgorithm describes an SSH public key algorithm known to the provider.
+// Algorithms are registered together with their key type, see KeyTypeImpl.
+type KeyAlgorithm struct {
+	// Name is the SSH algorithm name, e.g. ssh-ed25519
+	Name string
+	// Type is the key type the algorithm belongs to, set by the registry
+	Type KeyType
+	// Curve of ECDSA based algorithms
+	Curve Curve
+	// SecurityKey is set for FIDO algorithms
+	SecurityKey bool
+	// SSHFP is the algorithm number of SSHFP DNS records, 0 if there is none
+	SSHFP uint8
+}
+
+// LookupKeyAlgorithm returns the registered algorithm with the given SSH
+// algorithm name.
+func LookupKeyAlgorithm(name string) (KeyAlgorithm, bool) {
+	for keyType, impl := range keyTypes {
+		for _, alg := range impl.Algorithms {
+			if alg.Name == name {
+				alg.Type = keyType
+
+				return alg, true
+			}
+		}
+	}
+
+	return KeyAlgorithm{}, false
+}
+
+// KeyAlgorithms returns all registered algorithms, sorted by name.
+func KeyAlgorithms() []KeyAlgorithm {
+	var algs []KeyAlgorithm
+
+	for keyType, impl := range keyTypes {
+		for _, alg := range impl.Algorithms {
+			alg.Type = keyType
+			algs = append(algs, alg)
+		}
+	}
+
+	slices.SortFunc(algs, func(a, b KeyAlgorithm) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return algs
+}
+
+// keyTypeOfAlgorithm returns the implementation of the key type an SSH
+// algorithm belongs to, including key types which cannot be generated.
+func keyTypeOfAlgorithm(name string) (KeyTypeImpl, bool) {
+	alg, ok := LookupKeyAlgorithm(name)
+	if !ok {
+		return KeyTypeImpl{}, false
+	}
+
+	impl, ok := keyTypes[alg.Type]
+
+	return impl, ok
+}
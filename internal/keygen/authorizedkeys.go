@@ -0,0 +1,172 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrAuthorizedKeyOption indicates an option which cannot be written to an
+	// authorized_keys file.
+	ErrAuthorizedKeyOption = errors.New("invalid authorized_keys option")
+	// ErrAuthorizedKeyDuplicate indicates a key listed again with different
+	// options. sshd only uses the first line of a key, so the options of the
+	// later lines would be silently ignored.
+	ErrAuthorizedKeyDuplicate = errors.New("duplicate authorized_keys key with different options")
+)
+
+// AuthorizedKeyOptions holds the options restricting the use of a key in an
+// authorized_keys file.
+type AuthorizedKeyOptions struct {
+	// Command is forced on every login with the key
+	Command string
+	// From restricts the client addresses, e.g. 192.0.2.0/24 or *.example.com
+	From []string
+	// Environment variables set on login, requires PermitUserEnvironment
+	Environment map[string]string
+	// NoPTY prevents the allocation of a terminal
+	NoPTY bool
+	// Restrict disables all forwarding and terminal allocation
+	Restrict bool
+	// ExpiryTime - the zero time means the key never expires
+	ExpiryTime time.Time
+	// Principals accepted from certificates, only valid with CertAuthority
+	Principals []string
+	// CertAuthority trusts certificates signed by the key
+	CertAuthority bool
+}
+
+// List returns the options in authorized_keys syntax, quoting and escaping
+// their values.
+func (o *AuthorizedKeyOptions) List() ([]string, error) {
+	var options []string
+
+	if len(o.Principals) > 0 && !o.CertAuthority {
+		return nil, fmt.Errorf("%w: principals require cert-authority", ErrAuthorizedKeyOption)
+	}
+
+	if o.Restrict {
+		options = append(options, "restrict")
+	}
+
+	if o.CertAuthority {
+		options = append(options, "cert-authority")
+	}
+
+	quoted := func(name, value string) error {
+		value, err := quoteAuthorizedKeyOption(value)
+		if err != nil {
+			return err
+		}
+
+		options = append(options, name+"="+value)
+
+		return nil
+	}
+
+	if o.Command != "" {
+		if err := quoted("command", o.Command); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(o.From) > 0 {
+		if err := quoted("from", strings.Join(o.From, ",")); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(o.Environment)) {
+		if name == "" || strings.ContainsAny(name, "= \t") {
+			return nil, fmt.Errorf("%w: invalid environment variable name %q", ErrAuthorizedKeyOption, name)
+		}
+
+		if err := quoted("environment", name+"="+o.Environment[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if !o.ExpiryTime.IsZero() {
+		options = append(options, `expiry-time="`+o.ExpiryTime.UTC().Format("20060102150405")+`Z"`)
+	}
+
+	if o.NoPTY {
+		options = append(options, "no-pty")
+	}
+
+	if len(o.Principals) > 0 {
+		if err := quoted("principals", strings.Join(o.Principals, ",")); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, option := range options {
+		if strings.ContainsAny(option, "\r\n") {
+			return nil, fmt.Errorf("%w: line breaks are not allowed: %s", ErrAuthorizedKeyOption, option)
+		}
+	}
+
+	return options, nil
+}
+
+// MarshalAuthorizedKeys returns an authorized_keys file of the keys including
+// their options. Keys with the same SHA256 fingerprint and options as a
+// previous key are skipped, while different options are an error.
+func MarshalAuthorizedKeys(keys []*PublicKey) ([]byte, error) {
+	var out bytes.Buffer
+
+	seen := make(map[string][]string, len(keys))
+
+	for _, key := range keys {
+		if options, ok := seen[key.SHA256()]; ok {
+			if !slices.Equal(options, key.Options) {
+				return nil, fmt.Errorf("%w: %s", ErrAuthorizedKeyDuplicate, key.SHA256())
+			}
+
+			continue
+		}
+
+		seen[key.SHA256()] = key.Options
+
+		if len(key.Options) > 0 {
+			out.WriteString(strings.Join(key.Options, ",") + " ")
+		}
+
+		out.Write(key.Marshal())
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), nil
+}
+
+// quoteAuthorizedKeyOption quotes an option value. sshd only understands
+// escaped double quotes inside of quoted values, so a backslash in front of a
+// double quote or at the end of the value cannot be written.
+func quoteAuthorizedKeyOption(value string) (string, error) {
+	if strings.HasSuffix(value, `\`) || strings.Contains(value, `\"`) {
+		return "", fmt.Errorf("%w: backslashes are not allowed before a double quote or at the end: %s",
+			ErrAuthorizedKeyOption, value)
+	}
+
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`, nil
+}
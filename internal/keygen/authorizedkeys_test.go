@@ -0,0 +1,125 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestMarshalAuthorizedKeys(t *testing.T) {
+	t.Parallel()
+
+	bastion := testPublicKey(t)
+	ca := testPublicKey(t)
+
+	options := keygen.AuthorizedKeyOptions{
+		Command:     `echo "hello world"`,
+		From:        []string{"192.0.2.0/24", "*.example.com"},
+		Environment: map[string]string{"TERM": "xterm", "LANG": "C"},
+		NoPTY:       true,
+		Restrict:    true,
+		ExpiryTime:  time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)),
+	}
+
+	list, err := options.List()
+	if err != nil {
+		t.Fatalf("error formatting options: %v", err)
+	}
+
+	expected := []string{
+		"restrict",
+		`command="echo \"hello world\""`,
+		`from="192.0.2.0/24,*.example.com"`,
+		`environment="LANG=C"`,
+		`environment="TERM=xterm"`,
+		`expiry-time="20300102020405Z"`,
+		"no-pty",
+	}
+	if !slices.Equal(list, expected) {
+		t.Errorf("unexpected options:\n%q\n%q", list, expected)
+	}
+
+	caOptions := keygen.AuthorizedKeyOptions{CertAuthority: true, Principals: []string{"jane", "ops"}}
+
+	caList, err := caOptions.List()
+	if err != nil {
+		t.Fatalf("error formatting options: %v", err)
+	}
+
+	bastion.Options = list
+	ca.Options = caList
+	duplicate := *bastion
+
+	out, err := keygen.MarshalAuthorizedKeys([]*keygen.PublicKey{bastion, ca, &duplicate})
+	if err != nil {
+		t.Fatalf("error marshaling authorized keys: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected duplicate key to be skipped, got %q", out)
+	}
+
+	for i, want := range [][]string{list, {"cert-authority", `principals="jane,ops"`}} {
+		_, _, parsed, _, err := ssh.ParseAuthorizedKey([]byte(lines[i]))
+		if err != nil {
+			t.Fatalf("error parsing line %q: %v", lines[i], err)
+		}
+
+		if !slices.Equal(parsed, want) {
+			t.Errorf("line %d: unexpected options:\n%q\n%q", i, parsed, want)
+		}
+	}
+}
+
+func TestMarshalAuthorizedKeysDuplicate(t *testing.T) {
+	t.Parallel()
+
+	key := testPublicKey(t)
+	key.Options = []string{"restrict"}
+
+	duplicate := *key
+	duplicate.Options = []string{"no-pty"}
+
+	if _, err := keygen.MarshalAuthorizedKeys([]*keygen.PublicKey{key, &duplicate}); !errors.Is(
+		err, keygen.ErrAuthorizedKeyDuplicate,
+	) {
+		t.Errorf("expected ErrAuthorizedKeyDuplicate, got %v", err)
+	}
+}
+
+func TestAuthorizedKeyOptionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, options := range map[string]keygen.AuthorizedKeyOptions{
+		"principals without cert-authority": {Principals: []string{"jane"}},
+		"environment name":                  {Environment: map[string]string{"A=B": "C"}},
+		"line break":                        {Command: "true\nfalse"},
+		"trailing backslash":                {Command: `echo \`},
+		"backslash before quote":            {Environment: map[string]string{"A": `\"`}},
+	} {
+		if _, err := options.List(); !errors.Is(err, keygen.ErrAuthorizedKeyOption) {
+			t.Errorf("%s: expected ErrAuthorizedKeyOption, got %v", name, err)
+		}
+	}
+}
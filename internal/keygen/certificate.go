@@ -0,0 +1,145 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Critical options understood by OpenSSH.
+const (
+	CriticalOptionForceCommand   = "force-command"
+	CriticalOptionSourceAddress  = "source-address"
+	CriticalOptionVerifyRequired = "verify-required"
+)
+
+//nolint:gochecknoglobals
+var (
+	// SSHCertCriticalOptions lists the critical options understood by OpenSSH.
+	SSHCertCriticalOptions = []string{
+		CriticalOptionForceCommand,
+		CriticalOptionSourceAddress,
+		CriticalOptionVerifyRequired,
+	}
+	// SSHCertDefaultExtensions are the extensions ssh-keygen adds to user
+	// certificates by default.
+	SSHCertDefaultExtensions = []string{
+		"permit-X11-forwarding",
+		"permit-agent-forwarding",
+		"permit-port-forwarding",
+		"permit-pty",
+		"permit-user-rc",
+	}
+)
+
+// ErrCertificateValidity indicates that a certificate would expire before it
+// becomes valid.
+var ErrCertificateValidity = errors.New("certificate valid_before must be after valid_after")
+
+// CertificateConfig holds the configuration of an SSH certificate.
+type CertificateConfig struct {
+	// CertType is either ssh.UserCert or ssh.HostCert
+	CertType uint32
+	// PublicKey to sign, in authorized_keys format
+	PublicKey []byte
+	// KeyID identifies the certificate in the server logs
+	KeyID string
+	// Serial number of the certificate
+	Serial uint64
+	// Principals the certificate is valid for; empty means any principal
+	Principals []string
+	// ValidAfter - the zero time means valid from the beginning of time
+	ValidAfter time.Time
+	// ValidBefore - the zero time means valid forever
+	ValidBefore time.Time
+	// CriticalOptions such as force-command or source-address
+	CriticalOptions map[string]string
+	// Extensions such as permit-pty; the values are usually empty
+	Extensions map[string]string
+}
+
+// Certificate holds a signed SSH certificate.
+type Certificate struct {
+	Cert    *ssh.Certificate
+	Comment string
+}
+
+// SignCertificate signs the configured public key with the private key of
+// the key pair, using the key pair as certificate authority.
+func (s *SSHKeyPair) SignCertificate(conf *CertificateConfig) (*Certificate, error) {
+	key := s.PrivateKey()
+	if key == nil {
+		return nil, ErrMissingSSHKeys
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	pubKey, comment, _, _, err := ssh.ParseAuthorizedKey(conf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	validAfter := uint64(0)
+	if !conf.ValidAfter.IsZero() {
+		validAfter = uint64(conf.ValidAfter.Unix()) //nolint:gosec
+	}
+
+	validBefore := uint64(ssh.CertTimeInfinity)
+	if !conf.ValidBefore.IsZero() {
+		validBefore = uint64(conf.ValidBefore.Unix()) //nolint:gosec
+	}
+
+	if validBefore <= validAfter {
+		return nil, ErrCertificateValidity
+	}
+
+	cert := &ssh.Certificate{
+		Key:             pubKey,
+		Serial:          conf.Serial,
+		CertType:        conf.CertType,
+		KeyId:           conf.KeyID,
+		ValidPrincipals: conf.Principals,
+		ValidAfter:      validAfter,
+		ValidBefore:     validBefore,
+		Permissions: ssh.Permissions{
+			CriticalOptions: conf.CriticalOptions,
+			Extensions:      conf.Extensions,
+		},
+	}
+
+	if err = cert.SignCert(rand.Reader, signer); err != nil {
+		return nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	return &Certificate{Cert: cert, Comment: comment}, nil
+}
+
+// Marshal returns the certificate in OpenSSH format, ready to be stored next
+// to the private key as <key>-cert.pub.
+func (c *Certificate) Marshal() []byte {
+	ak := ssh.MarshalAuthorizedKey(c.Cert)
+
+	return bytes.TrimSpace(fmt.Appendf(bytes.TrimSpace(ak), " %s", c.Comment))
+}
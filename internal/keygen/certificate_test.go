@@ -0,0 +1,132 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSignUserCertificate(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		ca, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 2048})
+		if err != nil {
+			t.Fatalf("error creating CA key pair: %v", err)
+		}
+
+		user, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519, Comment: "user@example.com"})
+		if err != nil {
+			t.Fatalf("error creating user key pair: %v", err)
+		}
+
+		now := time.Now()
+		cert, err := ca.SignCertificate(&keygen.CertificateConfig{
+			CertType:        ssh.UserCert,
+			PublicKey:       user.PublicKey(),
+			KeyID:           "user",
+			Serial:          42,
+			Principals:      []string{"root"},
+			ValidAfter:      now.Add(-time.Minute),
+			ValidBefore:     now.Add(time.Hour),
+			CriticalOptions: map[string]string{keygen.CriticalOptionForceCommand: "/bin/true"},
+			Extensions:      map[string]string{"permit-pty": ""},
+		})
+		if err != nil {
+			t.Fatalf("%s: error signing certificate: %v", keyType, err)
+		}
+
+		if !bytes.HasSuffix(cert.Marshal(), []byte(" user@example.com")) {
+			t.Errorf("%s: certificate lost the public key comment: %s", keyType, cert.Marshal())
+		}
+
+		pub, _, _, _, err := ssh.ParseAuthorizedKey(cert.Marshal())
+		if err != nil {
+			t.Fatalf("%s: error parsing certificate: %v", keyType, err)
+		}
+
+		parsed, ok := pub.(*ssh.Certificate)
+		if !ok {
+			t.Fatalf("%s: expected a certificate, got %T", keyType, pub)
+		}
+
+		checker := ssh.CertChecker{
+			SupportedCriticalOptions: []string{keygen.CriticalOptionForceCommand},
+			IsUserAuthority: func(auth ssh.PublicKey) bool {
+				return bytes.Equal(auth.Marshal(), parsed.SignatureKey.Marshal())
+			},
+		}
+		if _, err = checker.Authenticate(testConnMetadata("root"), parsed); err != nil {
+			t.Errorf("%s: certificate rejected: %v", keyType, err)
+		}
+	}
+}
+
+func TestSignSecurityKeyCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error creating CA key pair: %v", err)
+	}
+
+	for _, publicKey := range []string{testSKEd25519PublicKey, testSKECDSAPublicKey} {
+		cert, err := ca.SignCertificate(&keygen.CertificateConfig{
+			CertType:   ssh.UserCert,
+			PublicKey:  []byte(publicKey),
+			Principals: []string{"jane"},
+		})
+		if err != nil {
+			t.Fatalf("error signing certificate: %v", err)
+		}
+
+		if !bytes.HasPrefix(cert.Marshal(), []byte("sk-")) || !bytes.HasSuffix(cert.Marshal(), []byte(" jane@example.com")) {
+			t.Errorf("unexpected certificate %s", cert.Marshal())
+		}
+	}
+}
+
+func TestSignCertificateInvalidValidity(t *testing.T) {
+	t.Parallel()
+
+	ca, _ := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	now := time.Now()
+
+	_, err := ca.SignCertificate(&keygen.CertificateConfig{
+		CertType:    ssh.UserCert,
+		PublicKey:   ca.PublicKey(),
+		ValidAfter:  now,
+		ValidBefore: now.Add(-time.Hour),
+	})
+	if err == nil {
+		t.Error("expected error for certificate expiring before it becomes valid")
+	}
+}
+
+type testConnMetadata string
+
+func (c testConnMetadata) User() string          { return string(c) }
+func (c testConnMetadata) SessionID() []byte     { return nil }
+func (c testConnMetadata) ClientVersion() []byte { return nil }
+func (c testConnMetadata) ServerVersion() []byte { return nil }
+func (c testConnMetadata) RemoteAddr() net.Addr  { return nil }
+func (c testConnMetadata) LocalAddr() net.Addr   { return nil }
@@ -0,0 +1,44 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// derivationSalt separates keys derived by the provider from other uses of
+// the same seed.
+const derivationSalt = "terraform-provider-sshkey/v1"
+
+// DeriveReader returns the deterministic entropy source for a key derived
+// from a seed. The key material is the output of HKDF-SHA256 (RFC 5869) with
+//
+//	IKM  = seed
+//	salt = "terraform-provider-sshkey/v1"
+//	info = "<type>:<curve>:<label>", e.g. "ecdsa:P384:web" or "ed25519::web"
+//
+// Ed25519 keys use the first 32 bytes as private key seed, ECDSA keys reduce
+// the first bit length of the group order plus 64 bits to the private scalar,
+// see generateECDSAKey. The same seed, type, curve and label therefore always
+// yield the same key, while different labels yield independent keys.
+func DeriveReader(seed []byte, keyType KeyType, curve Curve, label string) io.Reader {
+	info := string(keyType) + ":" + string(curve) + ":" + label
+
+	return hkdf.New(sha256.New, seed, []byte(derivationSalt), []byte(info))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestDeriveKey(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("correct horse battery staple")
+
+	// Known answers guard the derivation scheme against accidental changes.
+	expected := map[keygen.Curve]string{
+		"":          "SHA256:O0zdj+HhTyobQWh6JbQWjvAhZEkhUZrR88cnCkCnVK4",
+		keygen.P256: "SHA256:FSkHAe0dTgpM5x6+z+hHYAvLa1jqserFNW76Q1oOV8w",
+		keygen.P384: "SHA256:sXJPd92m6tia9zxyTWo/8MKkWtn5Bej1Yq9dOGWCgHI",
+		keygen.P521: "SHA256:ClZzB9DPdrhdEW+GHgHJCA2su1Hcxls5Dd/wzYds+tg",
+	}
+
+	for curve, fingerprint := range expected {
+		keyType := keygen.ECDSA
+		if curve == "" {
+			keyType = keygen.ED25519
+		}
+
+		conf := keygen.SSHKeyPairConfig{Type: keyType, Curve: curve, Seed: seed, Label: "web"}
+
+		key, err := keygen.New(&conf)
+		if err != nil {
+			t.Fatalf("%s: error deriving SSH key pair: %v", keyType, err)
+		}
+
+		if key.SHA256() != fingerprint {
+			t.Errorf("%s %s: expected %s, got %s", keyType, curve, fingerprint, key.SHA256())
+		}
+
+		conf.Label = "db"
+
+		other, err := keygen.New(&conf)
+		if err != nil {
+			t.Fatalf("%s: error deriving SSH key pair: %v", keyType, err)
+		}
+
+		if other.SHA256() == key.SHA256() {
+			t.Errorf("%s %s: expected different keys for different labels", keyType, curve)
+		}
+	}
+}
+
+func TestDeriveKeyNotDeterministic(t *testing.T) {
+	t.Parallel()
+
+	conf := keygen.SSHKeyPairConfig{Type: keygen.RSA, Seed: []byte("seed")}
+
+	if _, err := keygen.New(&conf); !errors.Is(err, keygen.ErrNotDeterministic) {
+		t.Errorf("expected ErrNotDeterministic, got %v", err)
+	}
+}
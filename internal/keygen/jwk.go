@@ -0,0 +1,301 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// jwk is a JSON Web Key as defined in RFC 7517, RFC 7518 and RFC 8037.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+}
+
+// jwks is a JSON Web Key Set as defined in RFC 7517, section 5.
+type jwks struct {
+	Keys []*jwk `json:"keys"`
+}
+
+// PublicKeyJWK returns the public key as JSON Web Key. The key ID is the
+// SHA256 fingerprint of the key.
+func (s *SSHKeyPair) PublicKeyJWK() []byte {
+	impl, ok := LookupKeyType(s.Type)
+	if !ok {
+		return nil
+	}
+
+	key, err := publicJWK(impl, s.publicKeyRaw(), s.SHA256())
+	if err != nil {
+		return nil
+	}
+
+	out, err := json.Marshal(key)
+	if err != nil {
+		return nil
+	}
+
+	return out
+}
+
+// PrivateKeyJWK returns the unencrypted private key as JSON Web Key. The key
+// ID is the SHA256 fingerprint of the key. A JSON Web Key cannot be encrypted,
+// so nil is returned when a passphrase is set.
+func (s *SSHKeyPair) PrivateKeyJWK() []byte {
+	if len(s.Passphrase) > 0 {
+		return nil
+	}
+
+	impl, ok := LookupKeyType(s.Type)
+	if !ok || impl.PrivateJWK == nil {
+		return nil
+	}
+
+	key, err := publicJWK(impl, s.publicKeyRaw(), s.SHA256())
+	if err != nil {
+		return nil
+	}
+
+	if err = impl.PrivateJWK(s.PrivateKeyRaw, key); err != nil {
+		return nil
+	}
+
+	out, err := json.Marshal(key)
+	if err != nil {
+		return nil
+	}
+
+	return out
+}
+
+// JWK returns the public key as JSON Web Key. The key ID is the SHA256
+// fingerprint of the key.
+func (p *PublicKey) JWK() ([]byte, error) {
+	key, err := p.jwk()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(key) //nolint:wrapcheck
+}
+
+// jwk returns the JSON Web Key of the public key. Security keys are rejected,
+// as their signatures are not plain EdDSA or ECDSA signatures.
+func (p *PublicKey) jwk() (*jwk, error) {
+	cryptoKey, ok := p.Key.(ssh.CryptoPublicKey)
+	if !ok || p.SecurityKey() {
+		return nil, UnsupportedKeyTypeError{p.Key.Type()}
+	}
+
+	impl, ok := keyTypeOfAlgorithm(p.Key.Type())
+	if !ok {
+		return nil, UnsupportedKeyTypeError{p.Key.Type()}
+	}
+
+	return publicJWK(impl, cryptoKey.CryptoPublicKey(), p.SHA256())
+}
+
+// MarshalJWKS returns the public keys as JSON Web Key Set.
+func MarshalJWKS(keys []*PublicKey) ([]byte, error) {
+	set := jwks{Keys: make([]*jwk, 0, len(keys))}
+
+	for _, key := range keys {
+		k, err := key.jwk()
+		if err != nil {
+			return nil, err
+		}
+
+		set.Keys = append(set.Keys, k)
+	}
+
+	return json.Marshal(set) //nolint:wrapcheck
+}
+
+// publicJWK returns the JSON Web Key of a public key. The key ID is derived
+// from the SHA256 fingerprint.
+func publicJWK(impl KeyTypeImpl, pub crypto.PublicKey, fingerprint string) (*jwk, error) {
+	if impl.PublicJWK == nil {
+		return nil, UnsupportedKeyTypeError{string(impl.Type)}
+	}
+
+	key := &jwk{
+		Kid: strings.TrimPrefix(fingerprint, "SHA256:"),
+		Use: "sig",
+	}
+
+	if err := impl.PublicJWK(pub, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// publicJWKRSAKey sets the members of RSA public keys (RFC 7518, section
+// 6.3.1).
+func publicJWKRSAKey(pub crypto.PublicKey, key *jwk) error {
+	rsaKey, err := keyAs[*rsa.PublicKey](pub)
+	if err != nil {
+		return err
+	}
+
+	key.Kty = "RSA"
+	key.Alg = "RS256"
+	key.N = encodeJWKInt(rsaKey.N)
+	key.E = encodeJWKInt(big.NewInt(int64(rsaKey.E)))
+
+	return nil
+}
+
+// privateJWKRSAKey sets the members of RSA private keys (RFC 7518, section
+// 6.3.2).
+func privateJWKRSAKey(priv crypto.PrivateKey, key *jwk) error {
+	rsaKey, err := keyAs[*rsa.PrivateKey](priv)
+	if err != nil {
+		return err
+	}
+
+	if len(rsaKey.Primes) != 2 { //nolint:mnd
+		return UnsupportedKeyTypeError{"multi-prime rsa"}
+	}
+
+	rsaKey.Precompute()
+
+	key.D = encodeJWKInt(rsaKey.D)
+	key.P = encodeJWKInt(rsaKey.Primes[0])
+	key.Q = encodeJWKInt(rsaKey.Primes[1])
+	key.DP = encodeJWKInt(rsaKey.Precomputed.Dp)
+	key.DQ = encodeJWKInt(rsaKey.Precomputed.Dq)
+	key.QI = encodeJWKInt(rsaKey.Precomputed.Qinv)
+
+	return nil
+}
+
+// publicJWKED25519Key sets the members of Ed25519 public keys (RFC 8037,
+// section 2).
+func publicJWKED25519Key(pub crypto.PublicKey, key *jwk) error {
+	if edKey, ok := pub.(*ed25519.PublicKey); ok {
+		pub = *edKey
+	}
+
+	edKey, err := keyAs[ed25519.PublicKey](pub)
+	if err != nil {
+		return err
+	}
+
+	key.Kty = "OKP"
+	key.Alg = "EdDSA"
+	key.Crv = "Ed25519"
+	key.X = base64.RawURLEncoding.EncodeToString(edKey)
+
+	return nil
+}
+
+// privateJWKED25519Key sets the private member of Ed25519 private keys, the
+// seed (RFC 8037, section 2).
+func privateJWKED25519Key(priv crypto.PrivateKey, key *jwk) error {
+	edKey, err := keyAs[*ed25519.PrivateKey](priv)
+	if err != nil {
+		return err
+	}
+
+	key.D = base64.RawURLEncoding.EncodeToString(edKey.Seed())
+
+	return nil
+}
+
+// publicJWKECDSAKey sets the members of ECDSA public keys (RFC 7518, section
+// 6.2.1).
+func publicJWKECDSAKey(pub crypto.PublicKey, key *jwk) error {
+	ecKey, err := keyAs[*ecdsa.PublicKey](pub)
+	if err != nil {
+		return err
+	}
+
+	point, err := ecKey.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	// The uncompressed point is 0x04 || X || Y.
+	size := (len(point) - 1) / 2 //nolint:mnd
+
+	key.Kty = "EC"
+	key.Crv = ecKey.Params().Name
+	key.Alg = jwkECDSAAlgorithm(ecKey.Params().BitSize)
+	key.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+size])
+	key.Y = base64.RawURLEncoding.EncodeToString(point[1+size:])
+
+	return nil
+}
+
+// privateJWKECDSAKey sets the private member of ECDSA private keys (RFC 7518,
+// section 6.2.2).
+func privateJWKECDSAKey(priv crypto.PrivateKey, key *jwk) error {
+	ecKey, err := keyAs[*ecdsa.PrivateKey](priv)
+	if err != nil {
+		return err
+	}
+
+	scalar, err := ecKey.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	key.D = base64.RawURLEncoding.EncodeToString(scalar)
+
+	return nil
+}
+
+// jwkECDSAAlgorithm returns the JWS algorithm of an ECDSA curve (RFC 7518,
+// section 3.4).
+func jwkECDSAAlgorithm(bitSize int) string {
+	switch bitSize {
+	case 256: //nolint:mnd
+		return "ES256"
+	case 384: //nolint:mnd
+		return "ES384"
+	default:
+		return "ES512"
+	}
+}
+
+// encodeJWKInt encodes an integer as unsigned big-endian base64url string.
+func encodeJWKInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
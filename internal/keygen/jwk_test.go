@@ -0,0 +1,201 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+type testJWK map[string]string
+
+func (k testJWK) bytes(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := base64.RawURLEncoding.DecodeString(k[name])
+	if err != nil {
+		t.Fatalf("invalid %s: %v", name, err)
+	}
+
+	return b
+}
+
+func (k testJWK) int(t *testing.T, name string) *big.Int {
+	t.Helper()
+
+	return new(big.Int).SetBytes(k.bytes(t, name))
+}
+
+// privateKey rebuilds the private key of a JWK.
+//
+//nolint:ireturn
+func (k testJWK) privateKey(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	switch k["kty"] {
+	case "RSA":
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: k.int(t, "n"), E: int(k.int(t, "e").Int64())},
+			D:         k.int(t, "d"),
+			Primes:    []*big.Int{k.int(t, "p"), k.int(t, "q")},
+		}
+
+		if err := key.Validate(); err != nil {
+			t.Fatalf("invalid RSA key: %v", err)
+		}
+
+		return key
+	case "OKP":
+		return ed25519.NewKeyFromSeed(k.bytes(t, "d"))
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+
+		key, err := ecdsa.ParseRawPrivateKey(curves[k["crv"]], k.bytes(t, "d"))
+		if err != nil {
+			t.Fatalf("invalid ECDSA key: %v", err)
+		}
+
+		return key
+	default:
+		t.Fatalf("unexpected key type %q", k["kty"])
+
+		return nil
+	}
+}
+
+func TestKeyPairJWK(t *testing.T) {
+	t.Parallel()
+
+	types := map[keygen.KeyType]string{keygen.RSA: "RSA", keygen.ED25519: "OKP", keygen.ECDSA: "EC"}
+
+	for _, keyType := range keygen.KeyTypes() {
+		key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 2048})
+		if err != nil {
+			t.Fatalf("error creating SSH key pair: %v", err)
+		}
+
+		var public, private testJWK
+
+		if err = json.Unmarshal(key.PublicKeyJWK(), &public); err != nil {
+			t.Fatalf("%s: invalid public JWK: %v", keyType, err)
+		}
+
+		if err = json.Unmarshal(key.PrivateKeyJWK(), &private); err != nil {
+			t.Fatalf("%s: invalid private JWK: %v", keyType, err)
+		}
+
+		if public["kty"] != types[keyType] {
+			t.Errorf("%s: unexpected kty %q", keyType, public["kty"])
+		}
+
+		if public["kid"] != strings.TrimPrefix(key.SHA256(), "SHA256:") {
+			t.Errorf("%s: unexpected kid %q", keyType, public["kid"])
+		}
+
+		if _, ok := public["d"]; ok {
+			t.Errorf("%s: public JWK contains the private key", keyType)
+		}
+
+		for name, value := range public {
+			if private[name] != value {
+				t.Errorf("%s: private JWK has %s %q, expected %q", keyType, name, private[name], value)
+			}
+		}
+
+		pub, err := ssh.NewPublicKey(private.privateKey(t).Public())
+		if err != nil {
+			t.Fatalf("%s: %v", keyType, err)
+		}
+
+		if ssh.FingerprintSHA256(pub) != key.SHA256() {
+			t.Errorf("%s: private JWK does not match key pair", keyType)
+		}
+	}
+}
+
+func TestKeyPairJWKEncrypted(t *testing.T) {
+	t.Parallel()
+
+	key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519, Passphrase: []byte("secret")})
+	if err != nil {
+		t.Fatalf("error creating SSH key pair: %v", err)
+	}
+
+	if key.PrivateKeyJWK() != nil {
+		t.Error("expected no private JWK for encrypted keys")
+	}
+
+	if key.PublicKeyJWK() == nil {
+		t.Error("expected a public JWK for encrypted keys")
+	}
+}
+
+func TestMarshalJWKS(t *testing.T) {
+	t.Parallel()
+
+	var keys []*keygen.PublicKey
+
+	for _, keyType := range keygen.KeyTypes() {
+		key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 2048})
+		if err != nil {
+			t.Fatalf("error creating SSH key pair: %v", err)
+		}
+
+		pub, err := keygen.ParseAuthorizedKey(key.PublicKey())
+		if err != nil {
+			t.Fatalf("error parsing public key: %v", err)
+		}
+
+		jwk, err := pub.JWK()
+		if err != nil {
+			t.Fatalf("%s: %v", keyType, err)
+		}
+
+		if string(jwk) != string(key.PublicKeyJWK()) {
+			t.Errorf("%s: JWK of parsed public key differs from key pair", keyType)
+		}
+
+		keys = append(keys, pub)
+	}
+
+	out, err := keygen.MarshalJWKS(keys)
+	if err != nil {
+		t.Fatalf("error creating JWKS: %v", err)
+	}
+
+	var set struct {
+		Keys []testJWK `json:"keys"`
+	}
+
+	if err = json.Unmarshal(out, &set); err != nil {
+		t.Fatalf("invalid JWKS: %v", err)
+	}
+
+	if len(set.Keys) != len(keys) {
+		t.Errorf("expected %d keys, got %d", len(keys), len(set.Keys))
+	}
+}
@@ -19,16 +19,15 @@ package keygen
 import (
 	"bytes"
 	"crypto"
-	"crypto/ecdsa"
-	"crypto/ed25519"
 	"crypto/elliptic"
-	"crypto/rand"
-	"crypto/rsa"
+	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
+	"strings"
 
 	"golang.org/x/crypto/ssh"
 )
@@ -43,13 +42,21 @@ const (
 	ECDSA   KeyType = "ecdsa"
 )
 
-const RsaDefaultBits = 4096
+// Curve represents the NIST curve of an ECDSA key.
+type Curve string
 
-//nolint:gochecknoglobals
-var (
-	SSHKeyTypes        = []KeyType{RSA, ED25519, ECDSA}
-	SSHKeyTypesStrings = []string{"rsa", "ed25519", "ecdsa"}
-	SSHRsaBits         = []int64{1024, 2048, 4096}
+// Supported ECDSA curves.
+const (
+	P256 Curve = "P256"
+	P384 Curve = "P384"
+	P521 Curve = "P521"
+)
+
+const (
+	RsaDefaultBits    = 4096
+	EcdsaDefaultCurve = P384
+
+	ed25519BitSize = 256
 )
 
 // ErrMissingSSHKeys indicates we're missing some keys that we expected to
@@ -73,6 +80,20 @@ func (e UnsupportedKeyTypeError) Error() string {
 	return err
 }
 
+// ErrNotDeterministic indicates that a key type cannot be generated from a
+// custom entropy source.
+var ErrNotDeterministic = errors.New("key type does not support deterministic key generation")
+
+// UnsupportedCurveError indicates an unsupported ECDSA curve.
+type UnsupportedCurveError struct {
+	Curve string
+}
+
+// Error implements the error interface for UnsupportedCurveError.
+func (e UnsupportedCurveError) Error() string {
+	return "unsupported ECDSA curve: " + e.Curve
+}
+
 // FilesystemError is used to signal there was a problem creating keys at the
 // filesystem-level. For example, when we're unable to create a directory to
 // store new SSH keys in.
@@ -95,12 +116,24 @@ func (e FilesystemError) Unwrap() error {
 type SSHKeyPairConfig struct {
 	// Type is the type of the SSH key pair.
 	Type KeyType
-	// Bits - RSA bit size
+	// Bits - RSA bit size, ignored for other key types
 	Bits uint16
+	// Curve - ECDSA curve, defaults to EcdsaDefaultCurve
+	Curve Curve
 	// Comment for the ssh key pair
 	Comment string
 	// Passphrase
 	Passphrase []byte
+	// Entropy is the source of the key material, defaults to the system random
+	// number generator
+	Entropy io.Reader
+	// Seed derives the key deterministically instead, see DeriveReader
+	Seed []byte
+	// Label distinguishes the keys derived from the same seed
+	Label string
+	// KDFRounds - bcrypt_pbkdf rounds of encrypted OPENSSH keys, defaults to
+	// OpenSSHDefaultKDFRounds
+	KDFRounds int
 }
 
 // SSHKeyPair holds a pair of SSH keys and associated methods.
@@ -108,85 +141,46 @@ type SSHKeyPair struct {
 	Passphrase    []byte
 	Type          KeyType
 	Bits          uint16
+	Curve         Curve
 	PrivateKeyRaw crypto.PrivateKey
 	Comment       string
+	KDFRounds     int
 }
 
 func (s *SSHKeyPair) pemBlock() (*pem.Block, error) {
-	key := s.PrivateKey()
-	if key == nil {
-		return nil, ErrMissingSSHKeys
-	}
-
-	switch s.Type {
-	case RSA, ED25519, ECDSA:
-		if len(s.Passphrase) > 0 {
-			//nolint:wrapcheck
-			return ssh.MarshalPrivateKeyWithPassphrase(key, s.Comment, nil)
-		}
-
-		//nolint:wrapcheck
-		return ssh.MarshalPrivateKey(key, s.Comment)
-	default:
+	impl, ok := LookupKeyType(s.Type)
+	if !ok {
 		return nil, UnsupportedKeyTypeError{string(s.Type)}
 	}
-}
 
-// generateED25519Keys creates a pair of EdD25519 keys for SSH auth.
-func (s *SSHKeyPair) generateED25519Keys() error {
-	// Generate keys
-	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
-	if err != nil {
-		return fmt.Errorf("failed to generate key: %w", err)
+	if s.PrivateKeyRaw == nil {
+		return nil, ErrMissingSSHKeys
 	}
 
-	s.PrivateKeyRaw = &privateKey
-
-	return nil
-}
-
-// generateED25519Keys creates a pair of EdD25519 keys for SSH auth.
-func (s *SSHKeyPair) generateECDSAKeys(curve elliptic.Curve) error {
-	// Generate keys
-	privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
-	if err != nil {
-		return fmt.Errorf("failed to generate key: %w", err)
+	block, err := impl.Marshal(s.PrivateKeyRaw, s.Comment)
+	if err != nil || len(s.Passphrase) == 0 {
+		return block, err
 	}
 
-	s.PrivateKeyRaw = privateKey
-
-	return nil
-}
-
-// generateRSAKeys creates a pair for RSA keys for SSH auth.
-func (s *SSHKeyPair) generateRSAKeys() error {
-	// Generate private key
-	privateKey, err := rsa.GenerateKey(rand.Reader, int(s.Bits))
-	if err != nil {
-		return fmt.Errorf("failed to generate key: %w", err)
+	rounds := s.KDFRounds
+	if rounds == 0 {
+		rounds = OpenSSHDefaultKDFRounds
 	}
-	// Validate private key
-	err = privateKey.Validate()
-	if err != nil {
-		return fmt.Errorf("failed to validate key: %w", err)
-	}
-
-	s.PrivateKeyRaw = privateKey
 
-	return nil
+	return encryptOpenSSH(block, s.Passphrase, rounds)
 }
 
 // PrivateKey returns the unencrypted private key.
 func (s *SSHKeyPair) PrivateKey() crypto.PrivateKey {
-	switch s.Type {
-	case RSA, ED25519, ECDSA:
-		return s.PrivateKeyRaw
-	default:
+	if _, ok := LookupKeyType(s.Type); !ok {
 		return nil
 	}
+
+	return s.PrivateKeyRaw
 }
 
-// PrivateKeyPEM returns the unencrypted private key in OPENSSH PEM format.
+// PrivateKeyPEM returns the private key in OPENSSH PEM format. The key is
+// encrypted when a passphrase is set.
 func (s *SSHKeyPair) PrivateKeyPEM() []byte {
 	block, err := s.pemBlock()
 	if err != nil {
@@ -197,38 +191,12 @@ func (s *SSHKeyPair) PrivateKeyPEM() []byte {
 }
 
 func (s *SSHKeyPair) publicKeyRaw() crypto.PublicKey {
-	var pkey crypto.PublicKey
-	// Prepare public key
-	switch s.Type {
-	case RSA:
-		key, ok := s.PrivateKeyRaw.(*rsa.PrivateKey)
-		if !ok {
-			return nil
-		}
-
-		pkey = key.Public()
-
-	case ED25519:
-		key, ok := s.PrivateKeyRaw.(*ed25519.PrivateKey)
-		if !ok {
-			return nil
-		}
-
-		pkey = key.Public()
-
-	case ECDSA:
-		key, ok := s.PrivateKeyRaw.(*ecdsa.PrivateKey)
-		if !ok {
-			return nil
-		}
-
-		pkey = key.Public()
-
-	default:
+	impl, ok := LookupKeyType(s.Type)
+	if !ok {
 		return nil
 	}
 
-	return pkey
+	return impl.PublicKey(s.PrivateKeyRaw)
 }
 
 // PublicKey returns the SSH public key (RFC 4253). Ready to be used in an
@@ -257,46 +225,149 @@ func (s *SSHKeyPair) SHA256() string {
 	return ssh.FingerprintSHA256(p)
 }
 
-// New generates an SSHKeyPair, which contains a pair of SSH keys.
+// New generates an SSHKeyPair, which contains a pair of SSH keys. The size
+// and curve default to those of the key type. Keys are derived from a seed
+// when configured, or read from a custom entropy source.
 func New(conf *SSHKeyPairConfig) (*SSHKeyPair, error) {
-	var err error
+	impl, ok := LookupKeyType(conf.Type)
+	if !ok {
+		return nil, UnsupportedKeyTypeError{string(conf.Type)}
+	}
 
 	if conf.Comment == "" {
 		conf.Comment = GetSSHKeyComment()
 	}
 
-	skeypair := &SSHKeyPair{
-		Type:       conf.Type,
-		Passphrase: conf.Passphrase,
-		Comment:    conf.Comment,
+	bits := impl.DefaultBits
+	if conf.Bits != 0 && len(impl.AllowedBits) > 0 {
+		bits = conf.Bits
 	}
 
-	if conf.Bits == 0 && conf.Type == RSA {
-		skeypair.Bits = RsaDefaultBits
-	} else {
-		skeypair.Bits = conf.Bits
+	curve := impl.DefaultCurve
+	if conf.Curve != "" && len(impl.Curves) > 0 {
+		curve = conf.Curve
 	}
 
-	switch conf.Type {
-	case ED25519:
-		err = skeypair.generateED25519Keys()
-	case RSA:
-		err = skeypair.generateRSAKeys()
-	case ECDSA:
-		err = skeypair.generateECDSAKeys(elliptic.P384())
-	default:
-		return nil, UnsupportedKeyTypeError{string(conf.Type)}
+	entropy := conf.Entropy
+	if len(conf.Seed) > 0 {
+		entropy = DeriveReader(conf.Seed, conf.Type, curve, conf.Label)
 	}
 
+	if entropy != nil && !impl.Deterministic {
+		return nil, fmt.Errorf("%w: %s", ErrNotDeterministic, conf.Type)
+	}
+
+	raw, err := impl.Generate(entropy, bits, curve)
 	if err != nil {
 		return nil, err
 	}
 
-	return skeypair, nil
+	key, bits, curve, _ := impl.Inspect(raw)
+
+	return &SSHKeyPair{
+		Type:          conf.Type,
+		Passphrase:    conf.Passphrase,
+		Comment:       conf.Comment,
+		KDFRounds:     conf.KDFRounds,
+		Bits:          bits,
+		Curve:         curve,
+		PrivateKeyRaw: key,
+	}, nil
+}
+
+// Parse reads a PEM encoded private key in OpenSSH, PKCS#1, PKCS#8 or SEC1
+// format and returns the matching SSHKeyPair. The passphrase is only used to
+// decrypt the key if it is encrypted. The comment is only available for keys
+// in OPENSSH format.
+func Parse(pemBytes, passphrase []byte) (*SSHKeyPair, error) {
+	var comment string
+
+	if block, _ := pem.Decode(pemBytes); block != nil && block.Type == openSSHBlockType {
+		decrypted, openSSHComment, err := decryptOpenSSH(block, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+
+		pemBytes, comment = pem.EncodeToMemory(decrypted), openSSHComment
+	}
+
+	raw, err := parseRawPrivateKey(pemBytes, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	skeypair := &SSHKeyPair{
+		Passphrase: passphrase,
+		Comment:    strings.TrimSpace(comment),
+	}
+
+	for _, keyType := range KeyTypes() {
+		impl := keyTypes[keyType]
+
+		key, bits, curve, ok := impl.Inspect(raw)
+		if !ok {
+			continue
+		}
+
+		skeypair.Type = keyType
+		skeypair.Bits = bits
+		skeypair.Curve = curve
+		skeypair.PrivateKeyRaw = key
+
+		return skeypair, nil
+	}
+
+	return nil, UnsupportedKeyTypeError{fmt.Sprintf("%T", raw)}
+}
+
+// parseRawPrivateKey parses OpenSSH, PKCS#1, PKCS#8 and SEC1 private keys.
+// Encrypted keys are decrypted with the passphrase.
+func parseRawPrivateKey(pemBytes, passphrase []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block != nil && block.Type == pemTypeEncryptedPKCS8 {
+		if len(passphrase) == 0 {
+			return nil, &ssh.PassphraseMissingError{}
+		}
+
+		der, err := decryptPKCS8(block.Bytes, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		return x509.ParsePKCS8PrivateKey(der) //nolint:wrapcheck
+	}
+
+	raw, err := ssh.ParseRawPrivateKey(pemBytes)
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) && len(passphrase) > 0 {
+		raw, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, passphrase)
+	}
+
+	return raw, err //nolint:wrapcheck
+}
+
+// elliptic returns the elliptic curve implementation of the curve.
+func (c Curve) elliptic() (elliptic.Curve, error) {
+	switch c {
+	case P256:
+		return elliptic.P256(), nil
+	case P384:
+		return elliptic.P384(), nil
+	case P521:
+		return elliptic.P521(), nil
+	default:
+		return nil, UnsupportedCurveError{string(c)}
+	}
+}
+
+// curveOf returns the Curve matching an elliptic curve implementation.
+func curveOf(curve elliptic.Curve) Curve {
+	return Curve(strings.ReplaceAll(curve.Params().Name, "-", ""))
 }
 
-// attaches a user@host suffix to a serialized public key. returns the original
-// pubkey if we can't get the username or host.
+// GetSSHKeyComment returns the default key comment user@host, or an empty
+// string if we can't get the username or host.
 func GetSSHKeyComment() string {
 	usr, err := user.Current()
 	if err != nil {
@@ -308,5 +379,5 @@ func GetSSHKeyComment() string {
 		return ""
 	}
 
-	return fmt.Sprintf("%s@%s\n", usr.Username, hostname)
+	return fmt.Sprintf("%s@%s", usr.Username, strings.TrimSpace(hostname))
 }
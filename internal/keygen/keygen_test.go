@@ -16,10 +16,13 @@ limitations under the License.
 package keygen_test
 
 import (
+	"crypto/x509"
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestNewSSHKeyPair(t *testing.T) {
@@ -93,7 +96,7 @@ func TestGenerateECDSAKeys(t *testing.T) {
 func TestGeneratePublicKeyWithEmptyDir(t *testing.T) {
 	t.Parallel()
 
-	for _, keyType := range keygen.SSHKeyTypes {
+	for _, keyType := range keygen.KeyTypes() {
 		func(t *testing.T) {
 			t.Helper()
 
@@ -113,7 +116,7 @@ func TestGeneratePublicKeyWithEmptyDir(t *testing.T) {
 func TestGenerateKeyWithPassphrase(t *testing.T) {
 	t.Parallel()
 
-	for _, keyType := range keygen.SSHKeyTypes {
+	for _, keyType := range keygen.KeyTypes() {
 		tpass := "testpass"
 
 		func(t *testing.T) {
@@ -145,10 +148,127 @@ func TestGenerateKeyWithPassphrase(t *testing.T) {
 func TestReadingKeyWithPassphrase(t *testing.T) {
 	t.Parallel()
 
-	for _, keyType := range keygen.SSHKeyTypes {
+	for _, keyType := range keygen.KeyTypes() {
 		c := keygen.SSHKeyPairConfig{Passphrase: []byte("test"), Type: keyType}
 		if _, err := keygen.New(&c); err != nil {
 			t.Fatalf("error reading SSH key pair: %v", err)
 		}
 	}
 }
+
+func TestParseKeyWithPassphrase(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		conf := keygen.SSHKeyPairConfig{Passphrase: []byte("test"), Type: keyType}
+
+		key, err := keygen.New(&conf)
+		if err != nil {
+			t.Fatalf("error creating SSH key pair: %v", err)
+		}
+
+		var missing *ssh.PassphraseMissingError
+
+		_, err = keygen.Parse(key.PrivateKeyPEM(), nil)
+		if !errors.As(err, &missing) || missing.PublicKey == nil {
+			t.Errorf("%s: expected PassphraseMissingError with public key, got %v", keyType, err)
+		}
+
+		if _, err = keygen.Parse(key.PrivateKeyPEM(), []byte("wrong")); !errors.Is(err, x509.IncorrectPasswordError) {
+			t.Errorf("%s: expected IncorrectPasswordError, got %v", keyType, err)
+		}
+
+		parsed, err := keygen.Parse(key.PrivateKeyPEM(), []byte("test"))
+		if err != nil {
+			t.Fatalf("%s: error reading SSH key pair: %v", keyType, err)
+		}
+
+		if parsed.Type != keyType {
+			t.Errorf("%s: parsed key has type %s", keyType, parsed.Type)
+		}
+
+		if parsed.SHA256() != key.SHA256() {
+			t.Errorf("%s: fingerprint mismatch after parsing", keyType)
+		}
+	}
+}
+
+func TestGenerateECDSACurves(t *testing.T) {
+	t.Parallel()
+
+	expected := map[keygen.Curve]string{
+		"":          "ecdsa-sha2-nistp384 ",
+		keygen.P256: "ecdsa-sha2-nistp256 ",
+		keygen.P384: "ecdsa-sha2-nistp384 ",
+		keygen.P521: "ecdsa-sha2-nistp521 ",
+	}
+
+	for curve, prefix := range expected {
+		conf := keygen.SSHKeyPairConfig{Type: keygen.ECDSA, Curve: curve}
+
+		key, err := keygen.New(&conf)
+		if err != nil {
+			t.Fatalf("error creating SSH key pair: %v", err)
+		}
+
+		if !strings.HasPrefix(string(key.PublicKey()), prefix) {
+			t.Errorf("curve %q: expected public key with prefix %q, got %q", curve, prefix, key.PublicKey())
+		}
+
+		parsed, err := keygen.Parse(key.PrivateKeyPEM(), nil)
+		if err != nil {
+			t.Fatalf("error reading SSH key pair: %v", err)
+		}
+
+		if parsed.Curve != key.Curve {
+			t.Errorf("curve %q: parsed key has curve %q", key.Curve, parsed.Curve)
+		}
+	}
+
+	conf := keygen.SSHKeyPairConfig{Type: keygen.ECDSA, Curve: "P192"}
+	if _, err := keygen.New(&conf); err == nil {
+		t.Error("expected error for unsupported curve")
+	}
+}
+
+func TestParseKeyComment(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		for _, passphrase := range []string{"", "test"} {
+			conf := keygen.SSHKeyPairConfig{
+				Passphrase: []byte(passphrase),
+				Type:       keyType,
+				Bits:       2048,
+				Comment:    "user@example.com",
+			}
+
+			key, err := keygen.New(&conf)
+			if err != nil {
+				t.Fatalf("error creating SSH key pair: %v", err)
+			}
+
+			parsed, err := keygen.Parse(key.PrivateKeyPEM(), []byte(passphrase))
+			if err != nil {
+				t.Fatalf("%s: error reading SSH key pair: %v", keyType, err)
+			}
+
+			if parsed.Comment != "user@example.com" {
+				t.Errorf("%s: unexpected comment %q", keyType, parsed.Comment)
+			}
+		}
+	}
+}
+
+func TestDefaultKeyComment(t *testing.T) {
+	t.Parallel()
+
+	key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error creating SSH key pair: %v", err)
+	}
+
+	if key.Comment != keygen.GetSSHKeyComment() || strings.TrimSpace(key.Comment) != key.Comment {
+		t.Errorf("unexpected default comment %q", key.Comment)
+	}
+}
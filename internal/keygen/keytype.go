@@ -0,0 +1,353 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// KeyTypeImpl implements a key type which can be generated by the provider.
+type KeyTypeImpl struct {
+	// Type is the name of the key type, e.g. rsa
+	Type KeyType
+	// DefaultBits is the key size used when no size is configured
+	DefaultBits uint16
+	// AllowedBits lists the configurable key sizes; empty for key types with
+	// a fixed or curve defined size
+	AllowedBits []int64
+	// Curves lists the supported curves of elliptic curve key types
+	Curves []Curve
+	// DefaultCurve is the curve used when no curve is configured
+	DefaultCurve Curve
+	// Deterministic is set when Generate reads all key material from the
+	// given entropy source, so that a deterministic source yields the same
+	// key every time
+	Deterministic bool
+	// Generate creates a new private key of the given size and curve. A nil
+	// entropy source means the system random number generator.
+	Generate func(entropy io.Reader, bits uint16, curve Curve) (crypto.PrivateKey, error)
+	// Inspect returns the normalized private key together with its size and
+	// curve; ok is false when the key belongs to another key type
+	Inspect func(key crypto.PrivateKey) (normalized crypto.PrivateKey, bits uint16, curve Curve, ok bool)
+	// PublicKey returns the public key of a private key of this type
+	PublicKey func(key crypto.PrivateKey) crypto.PublicKey
+	// Marshal encodes the unencrypted private key as OPENSSH PRIVATE KEY block
+	Marshal func(key crypto.PrivateKey, comment string) (*pem.Block, error)
+	// MarshalTraditional encodes the unencrypted private key in the
+	// traditional PEM format of OpenSSL; nil for key types without one
+	MarshalTraditional func(key crypto.PrivateKey) (*pem.Block, error)
+	// MarshalPuTTY encodes the key specific private part of PuTTY private key
+	// files
+	MarshalPuTTY func(key crypto.PrivateKey) ([]byte, error)
+	// PublicJWK sets the key specific members of the JSON Web Key of a public
+	// key
+	PublicJWK func(pub crypto.PublicKey, key *jwk) error
+	// PrivateJWK sets the private members of the JSON Web Key of a private key
+	PrivateJWK func(priv crypto.PrivateKey, key *jwk) error
+	// PublicKeyBits returns the size of a public key of this type in bits
+	PublicKeyBits func(pub crypto.PublicKey) int
+	// OpenSSHKeyFields is the number of key specific fields that precede the
+	// comment in the private section of OPENSSH PRIVATE KEY blocks
+	OpenSSHKeyFields int
+	// Algorithms lists the SSH public key algorithms of the key type
+	Algorithms []KeyAlgorithm
+}
+
+//nolint:gochecknoglobals
+var keyTypes = map[KeyType]KeyTypeImpl{}
+
+func init() {
+	for _, impl := range []KeyTypeImpl{
+		{
+			Type:               RSA,
+			DefaultBits:        RsaDefaultBits,
+			AllowedBits:        []int64{1024, 2048, 4096},
+			Generate:           generateRSAKey,
+			Inspect:            inspectRSAKey,
+			PublicKey:          publicKeyOf[*rsa.PrivateKey],
+			Marshal:            marshalOpenSSH,
+			MarshalTraditional: marshalTraditionalRSAKey,
+			MarshalPuTTY:       marshalPuTTYRSAKey,
+			PublicJWK:          publicJWKRSAKey,
+			PrivateJWK:         privateJWKRSAKey,
+			PublicKeyBits:      bitsRSAKey,
+			OpenSSHKeyFields:   6,
+			Algorithms: []KeyAlgorithm{
+				{Name: ssh.KeyAlgoRSA, SSHFP: SSHFPAlgorithmRSA},
+			},
+		},
+		{
+			Type:             ED25519,
+			DefaultBits:      ed25519BitSize,
+			Deterministic:    true,
+			Generate:         generateED25519Key,
+			Inspect:          inspectED25519Key,
+			PublicKey:        publicKeyOf[*ed25519.PrivateKey],
+			Marshal:          marshalOpenSSH,
+			MarshalPuTTY:     marshalPuTTYED25519Key,
+			PublicJWK:        publicJWKED25519Key,
+			PrivateJWK:       privateJWKED25519Key,
+			PublicKeyBits:    bitsED25519Key,
+			OpenSSHKeyFields: 2,
+			Algorithms: []KeyAlgorithm{
+				{Name: ssh.KeyAlgoED25519, SSHFP: SSHFPAlgorithmED25519},
+			},
+		},
+		{
+			Type:               ECDSA,
+			Curves:             []Curve{P256, P384, P521},
+			DefaultCurve:       EcdsaDefaultCurve,
+			Deterministic:      true,
+			Generate:           generateECDSAKey,
+			Inspect:            inspectECDSAKey,
+			PublicKey:          publicKeyOf[*ecdsa.PrivateKey],
+			Marshal:            marshalOpenSSH,
+			MarshalTraditional: marshalTraditionalECDSAKey,
+			MarshalPuTTY:       marshalPuTTYECDSAKey,
+			PublicJWK:          publicJWKECDSAKey,
+			PrivateJWK:         privateJWKECDSAKey,
+			PublicKeyBits:      bitsECDSAKey,
+			OpenSSHKeyFields:   3,
+			Algorithms: []KeyAlgorithm{
+				{Name: ssh.KeyAlgoECDSA256, Curve: P256, SSHFP: SSHFPAlgorithmECDSA},
+				{Name: ssh.KeyAlgoECDSA384, Curve: P384, SSHFP: SSHFPAlgorithmECDSA},
+				{Name: ssh.KeyAlgoECDSA521, Curve: P521, SSHFP: SSHFPAlgorithmECDSA},
+			},
+		},
+		{
+			Type:          ED25519SK,
+			PublicKeyBits: bitsED25519Key,
+			Algorithms: []KeyAlgorithm{
+				{Name: ssh.KeyAlgoSKED25519, SecurityKey: true},
+			},
+		},
+		{
+			Type:          ECDSASK,
+			PublicKeyBits: bitsECDSAKey,
+			Algorithms: []KeyAlgorithm{
+				{Name: ssh.KeyAlgoSKECDSA256, Curve: P256, SecurityKey: true},
+			},
+		},
+	} {
+		RegisterKeyType(impl)
+	}
+}
+
+// RegisterKeyType makes a key type and its SSH public key algorithms known to
+// the provider. Key types without Generate function, like security keys, can
+// be parsed but not generated. Registering a key type again replaces the
+// previous implementation. It is meant to be called during initialization, as
+// the registry is not safe for concurrent modification.
+func RegisterKeyType(impl KeyTypeImpl) {
+	keyTypes[impl.Type] = impl
+}
+
+// LookupKeyType returns the implementation of a registered key type which can
+// be generated.
+func LookupKeyType(keyType KeyType) (KeyTypeImpl, bool) {
+	impl, ok := keyTypes[keyType]
+
+	return impl, ok && impl.Generate != nil
+}
+
+// KeyTypes returns the names of all registered key types which can be
+// generated, sorted by name.
+func KeyTypes() []KeyType {
+	names := make([]KeyType, 0, len(keyTypes))
+
+	for name, impl := range keyTypes {
+		if impl.Generate != nil {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// KeySizes returns the configurable key sizes of all registered key types.
+func KeySizes() []int64 {
+	var sizes []int64
+
+	for _, name := range KeyTypes() {
+		for _, bits := range keyTypes[name].AllowedBits {
+			if !slices.Contains(sizes, bits) {
+				sizes = append(sizes, bits)
+			}
+		}
+	}
+
+	slices.Sort(sizes)
+
+	return sizes
+}
+
+// Curves returns the curves of all registered key types.
+func Curves() []Curve {
+	var curves []Curve
+
+	for _, name := range KeyTypes() {
+		for _, curve := range keyTypes[name].Curves {
+			if !slices.Contains(curves, curve) {
+				curves = append(curves, curve)
+			}
+		}
+	}
+
+	return curves
+}
+
+// marshalOpenSSH encodes a private key in OPENSSH format.
+func marshalOpenSSH(key crypto.PrivateKey, comment string) (*pem.Block, error) {
+	//nolint:wrapcheck
+	return ssh.MarshalPrivateKey(key, comment)
+}
+
+// publicKeyOf returns the public key of private keys of type K.
+func publicKeyOf[K interface{ Public() crypto.PublicKey }](key crypto.PrivateKey) crypto.PublicKey {
+	priv, ok := key.(K)
+	if !ok {
+		return nil
+	}
+
+	return priv.Public()
+}
+
+// keyAs returns the key as type K, or an UnsupportedKeyTypeError for keys of
+// other types.
+func keyAs[K any](key any) (K, error) {
+	typed, ok := key.(K)
+	if !ok {
+		return typed, UnsupportedKeyTypeError{fmt.Sprintf("%T", key)}
+	}
+
+	return typed, nil
+}
+
+// generateRSAKey creates a RSA key for SSH auth. RSA key generation always uses
+// the system random number generator.
+func generateRSAKey(_ io.Reader, bits uint16, _ Curve) (crypto.PrivateKey, error) {
+	// Generate private key
+	privateKey, err := rsa.GenerateKey(rand.Reader, int(bits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	// Validate private key
+	err = privateKey.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+func inspectRSAKey(key crypto.PrivateKey) (crypto.PrivateKey, uint16, Curve, bool) {
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, 0, "", false
+	}
+
+	return rsaKey, uint16(rsaKey.N.BitLen()), "", true //nolint:gosec
+}
+
+// generateED25519Key creates an Ed25519 key for SSH auth. The private key seed
+// is read from the entropy source (RFC 8032, section 5.1.5).
+func generateED25519Key(entropy io.Reader, _ uint16, _ Curve) (crypto.PrivateKey, error) {
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := io.ReadFull(entropy, seed); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+
+	return &privateKey, nil
+}
+
+func inspectED25519Key(key crypto.PrivateKey) (crypto.PrivateKey, uint16, Curve, bool) {
+	switch edKey := key.(type) {
+	case ed25519.PrivateKey:
+		return &edKey, ed25519BitSize, "", true
+	case *ed25519.PrivateKey:
+		return edKey, ed25519BitSize, "", true
+	default:
+		return nil, 0, "", false
+	}
+}
+
+// generateECDSAKey creates an ECDSA key on the given curve for SSH auth. Keys
+// from a custom entropy source use the "extra random bits" method of FIPS
+// 186-5, appendix A.2.1: the private scalar is (c mod (n-1)) + 1, where c is
+// read as big-endian integer of the bit length of n plus 64 bits.
+func generateECDSAKey(entropy io.Reader, _ uint16, curve Curve) (crypto.PrivateKey, error) {
+	ellipticCurve, err := curve.elliptic()
+	if err != nil {
+		return nil, err
+	}
+
+	if entropy == nil {
+		privateKey, err := ecdsa.GenerateKey(ellipticCurve, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+
+		return privateKey, nil
+	}
+
+	order := ellipticCurve.Params().N
+	size := (order.BitLen() + 7) / 8               //nolint:mnd
+	extra := make([]byte, (order.BitLen()+64+7)/8) //nolint:mnd
+
+	if _, err = io.ReadFull(entropy, extra); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	nMinusOne := new(big.Int).Sub(order, big.NewInt(1))
+	scalar := new(big.Int).SetBytes(extra)
+	scalar.Mod(scalar, nMinusOne)
+	scalar.Add(scalar, big.NewInt(1))
+
+	privateKey, err := ecdsa.ParseRawPrivateKey(ellipticCurve, scalar.FillBytes(make([]byte, size)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+func inspectECDSAKey(key crypto.PrivateKey) (crypto.PrivateKey, uint16, Curve, bool) {
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, 0, "", false
+	}
+
+	return ecKey, uint16(ecKey.Curve.Params().BitSize), curveOf(ecKey.Curve), true //nolint:gosec
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	if got := keygen.KeyTypes(); !slices.Equal(got, []keygen.KeyType{keygen.ECDSA, keygen.ED25519, keygen.RSA}) {
+		t.Errorf("unexpected key types %v", got)
+	}
+
+	if got := keygen.KeySizes(); !slices.Equal(got, []int64{1024, 2048, 4096}) {
+		t.Errorf("unexpected key sizes %v", got)
+	}
+
+	if got := keygen.Curves(); !slices.Equal(got, []keygen.Curve{keygen.P256, keygen.P384, keygen.P521}) {
+		t.Errorf("unexpected curves %v", got)
+	}
+
+	if _, ok := keygen.LookupKeyType(keygen.ED25519SK); ok {
+		t.Error("security keys cannot be generated")
+	}
+}
+
+func TestKeyTypeDefaults(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		impl, _ := keygen.LookupKeyType(keyType)
+
+		key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 2048, Curve: keygen.P256})
+		if err != nil {
+			t.Fatalf("%s: error creating SSH key pair: %v", keyType, err)
+		}
+
+		if len(impl.AllowedBits) == 0 && impl.DefaultBits != 0 && key.Bits != impl.DefaultBits {
+			t.Errorf("%s: expected fixed size %d, got %d", keyType, impl.DefaultBits, key.Bits)
+		}
+
+		if len(impl.AllowedBits) > 0 && key.Bits != 2048 {
+			t.Errorf("%s: expected configured size 2048, got %d", keyType, key.Bits)
+		}
+
+		if len(impl.Curves) == 0 && key.Curve != "" {
+			t.Errorf("%s: unexpected curve %s", keyType, key.Curve)
+		}
+
+		if len(impl.Curves) > 0 && key.Curve != keygen.P256 {
+			t.Errorf("%s: expected configured curve P256, got %s", keyType, key.Curve)
+		}
+	}
+
+	if _, err := keygen.New(&keygen.SSHKeyPairConfig{Type: "dsa"}); err == nil {
+		t.Error("expected error for unregistered key type")
+	}
+}
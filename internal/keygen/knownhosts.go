@@ -0,0 +1,176 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Markers of known_hosts lines.
+const (
+	KnownHostsMarkerCertAuthority = "cert-authority"
+	KnownHostsMarkerRevoked       = "revoked"
+)
+
+// knownHostsDefaultPort is the port which is omitted from host entries.
+const knownHostsDefaultPort = 22
+
+// KnownHostsMarkers lists the markers understood by OpenSSH.
+//
+//nolint:gochecknoglobals
+var KnownHostsMarkers = []string{
+	KnownHostsMarkerCertAuthority,
+	KnownHostsMarkerRevoked,
+}
+
+var (
+	// ErrKnownHostsPattern indicates an attempt to hash a host pattern.
+	ErrKnownHostsPattern = errors.New("host patterns cannot be hashed")
+	// ErrKnownHostsMarker indicates a marker OpenSSH does not understand.
+	ErrKnownHostsMarker = errors.New("unsupported known_hosts marker")
+	// ErrKnownHostsNoHosts indicates a known_hosts line without hosts.
+	ErrKnownHostsNoHosts = errors.New("known_hosts line without hosts")
+	// ErrKnownHostsHost indicates a host which would break the known_hosts
+	// line, e.g. by injecting another host, marker or line.
+	ErrKnownHostsHost = errors.New("invalid known_hosts host")
+)
+
+// KnownHost holds the hosts a public key is valid for.
+type KnownHost struct {
+	// Hosts are host names, addresses or patterns like *.example.com
+	Hosts []string
+	// Port of the SSH server; 0 means the default port 22
+	Port int
+	// Key is the host key, or the CA key of @cert-authority lines
+	Key *PublicKey
+	// Marker is empty, cert-authority or revoked
+	Marker string
+}
+
+// Marshal returns the known_hosts lines of the host. Hashed host names are
+// written one per line, as OpenSSH only matches a single hashed name per
+// line.
+func (h *KnownHost) Marshal(hash bool) ([]byte, error) {
+	if len(h.Hosts) == 0 {
+		return nil, ErrKnownHostsNoHosts
+	}
+
+	prefix := ""
+
+	if h.Marker != "" {
+		if !slices.Contains(KnownHostsMarkers, h.Marker) {
+			return nil, fmt.Errorf("%w: %s", ErrKnownHostsMarker, h.Marker)
+		}
+
+		prefix = "@" + h.Marker + " "
+	}
+
+	key := bytes.TrimSpace(ssh.MarshalAuthorizedKey(h.Key.Key))
+
+	entries := make([]string, 0, len(h.Hosts))
+	for _, host := range h.Hosts {
+		if err := ValidateKnownHost(host); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, knownHostsEntry(host, h.Port))
+	}
+
+	if !hash {
+		return fmt.Appendf(nil, "%s%s %s\n", prefix, strings.Join(entries, ","), key), nil
+	}
+
+	var out bytes.Buffer
+
+	for _, entry := range entries {
+		if strings.ContainsAny(entry, "*?!") {
+			return nil, fmt.Errorf("%w: %s", ErrKnownHostsPattern, entry)
+		}
+
+		fmt.Fprintf(&out, "%s%s %s\n", prefix, hashKnownHost(entry, h.Key.Key.Marshal()), key)
+	}
+
+	return out.Bytes(), nil
+}
+
+// MarshalKnownHosts returns a known_hosts document of the hosts.
+func MarshalKnownHosts(hosts []KnownHost, hash bool) ([]byte, error) {
+	var out bytes.Buffer
+
+	for i := range hosts {
+		line, err := hosts[i].Marshal(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Write(line)
+	}
+
+	return out.Bytes(), nil
+}
+
+// ValidateKnownHost returns an error for hosts which cannot be written to a
+// known_hosts line as is. Whitespace and commas separate fields and hosts,
+// a leading @ starts a marker, | a hashed host and # a comment.
+func ValidateKnownHost(host string) error {
+	switch {
+	case host == "":
+		return fmt.Errorf("%w: empty host", ErrKnownHostsHost)
+	case strings.ContainsAny(host[:1], "@|#"):
+		return fmt.Errorf("%w: %q must not start with %q", ErrKnownHostsHost, host, host[:1])
+	case strings.ContainsFunc(host, func(r rune) bool { return r == ',' || unicode.IsSpace(r) || unicode.IsControl(r) }):
+		return fmt.Errorf("%w: %q must not contain commas, whitespace or control characters", ErrKnownHostsHost, host)
+	}
+
+	return nil
+}
+
+// knownHostsEntry returns the host entry of a host, i.e. [host]:port for
+// non-default ports.
+func knownHostsEntry(host string, port int) string {
+	if port == 0 || port == knownHostsDefaultPort {
+		return host
+	}
+
+	return "[" + host + "]:" + strconv.Itoa(port)
+}
+
+// hashKnownHost hashes a host entry like ssh-keygen -H. The salt is derived
+// from the host entry and key instead of being random, so that the same input
+// always yields the same document.
+func hashKnownHost(entry string, key []byte) string {
+	mac := hmac.New(sha1.New, key)
+	mac.Write([]byte(entry))
+	salt := mac.Sum(nil)
+
+	mac = hmac.New(sha1.New, salt)
+	mac.Write([]byte(entry))
+
+	encoding := base64.StdEncoding
+
+	return "|1|" + encoding.EncodeToString(salt) + "|" + encoding.EncodeToString(mac.Sum(nil))
+}
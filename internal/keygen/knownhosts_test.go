@@ -0,0 +1,208 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestMarshalKnownHosts(t *testing.T) {
+	t.Parallel()
+
+	host := testPublicKey(t)
+	revoked := testPublicKey(t)
+
+	ca, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error creating CA key pair: %v", err)
+	}
+
+	caKey, err := keygen.ParseAuthorizedKey(ca.PublicKey())
+	if err != nil {
+		t.Fatalf("error parsing CA public key: %v", err)
+	}
+
+	hosts := []keygen.KnownHost{
+		{Hosts: []string{"example.com", "192.0.2.1"}, Port: 2222, Key: host},
+		{Hosts: []string{"*.example.com"}, Key: caKey, Marker: keygen.KnownHostsMarkerCertAuthority},
+		{Hosts: []string{"*"}, Key: revoked, Marker: keygen.KnownHostsMarkerRevoked},
+	}
+
+	plain, err := keygen.MarshalKnownHosts(hosts, false)
+	if err != nil {
+		t.Fatalf("error creating known_hosts: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", plain)
+	}
+
+	if !strings.HasPrefix(lines[0], "[example.com]:2222,[192.0.2.1]:2222 ssh-ed25519 ") {
+		t.Errorf("unexpected host line %q", lines[0])
+	}
+
+	if !strings.HasPrefix(lines[1], "@cert-authority *.example.com ssh-ed25519 ") {
+		t.Errorf("unexpected cert-authority line %q", lines[1])
+	}
+
+	callback := testKnownHostsCallback(t, plain)
+
+	if err = callback("example.com:2222", testTCPAddr(2222), host.Key); err != nil {
+		t.Errorf("host key rejected: %v", err)
+	}
+
+	var keyErr *knownhosts.KeyError
+	if err = callback("example.com:2222", testTCPAddr(2222), caKey.Key); !errors.As(err, &keyErr) {
+		t.Errorf("expected key mismatch, got %v", err)
+	}
+
+	var revokedErr *knownhosts.RevokedError
+	if err = callback("example.com:22", testTCPAddr(22), revoked.Key); !errors.As(err, &revokedErr) {
+		t.Errorf("expected revoked key, got %v", err)
+	}
+
+	cert, err := ca.SignCertificate(&keygen.CertificateConfig{
+		CertType:   ssh.HostCert,
+		PublicKey:  host.Marshal(),
+		Principals: []string{"web.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("error signing host certificate: %v", err)
+	}
+
+	if err = callback("web.example.com:22", testTCPAddr(22), cert.Cert); err != nil {
+		t.Errorf("host certificate rejected: %v", err)
+	}
+}
+
+func TestMarshalKnownHostsHashed(t *testing.T) {
+	t.Parallel()
+
+	host := testPublicKey(t)
+	hosts := []keygen.KnownHost{{Hosts: []string{"example.com", "192.0.2.1"}, Port: 2222, Key: host}}
+
+	hashed, err := keygen.MarshalKnownHosts(hosts, true)
+	if err != nil {
+		t.Fatalf("error creating known_hosts: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(hashed), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per host, got %q", hashed)
+	}
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "|1|") || strings.Contains(line, "example.com") {
+			t.Errorf("host name not hashed: %q", line)
+		}
+	}
+
+	again, _ := keygen.MarshalKnownHosts(hosts, true)
+	if string(again) != string(hashed) {
+		t.Errorf("hashed known_hosts differ between runs:\n%s\n%s", hashed, again)
+	}
+
+	callback := testKnownHostsCallback(t, hashed)
+
+	for _, address := range []string{"example.com:2222", "192.0.2.1:2222"} {
+		if err = callback(address, testTCPAddr(2222), host.Key); err != nil {
+			t.Errorf("%s: host key rejected: %v", address, err)
+		}
+	}
+
+	if err = callback("example.com:22", testTCPAddr(22), host.Key); err == nil {
+		t.Error("host key accepted for the default port")
+	}
+}
+
+func TestMarshalKnownHostsInvalid(t *testing.T) {
+	t.Parallel()
+
+	host := testPublicKey(t)
+
+	for name, test := range map[string]struct {
+		host keygen.KnownHost
+		hash bool
+		err  error
+	}{
+		"no hosts": {host: keygen.KnownHost{Key: host}, err: keygen.ErrKnownHostsNoHosts},
+		"unknown marker": {
+			host: keygen.KnownHost{Hosts: []string{"a"}, Key: host, Marker: "trusted"},
+			err:  keygen.ErrKnownHostsMarker,
+		},
+		"hashed pattern": {host: keygen.KnownHost{Hosts: []string{"*.a"}, Key: host}, hash: true, err: keygen.ErrKnownHostsPattern},
+		"empty host":     {host: keygen.KnownHost{Hosts: []string{""}, Key: host}, err: keygen.ErrKnownHostsHost},
+		"comma":          {host: keygen.KnownHost{Hosts: []string{"a,b"}, Key: host}, err: keygen.ErrKnownHostsHost},
+		"whitespace":     {host: keygen.KnownHost{Hosts: []string{"a b"}, Key: host}, err: keygen.ErrKnownHostsHost},
+		"newline": {
+			host: keygen.KnownHost{Hosts: []string{"a\n@cert-authority *"}, Key: host},
+			err:  keygen.ErrKnownHostsHost,
+		},
+		"marker":      {host: keygen.KnownHost{Hosts: []string{"@revoked"}, Key: host}, err: keygen.ErrKnownHostsHost},
+		"hashed host": {host: keygen.KnownHost{Hosts: []string{"|1|a|b"}, Key: host}, err: keygen.ErrKnownHostsHost},
+		"comment":     {host: keygen.KnownHost{Hosts: []string{"#a"}, Key: host}, err: keygen.ErrKnownHostsHost},
+	} {
+		if _, err := test.host.Marshal(test.hash); !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", name, test.err, err)
+		}
+	}
+}
+
+func testPublicKey(t *testing.T) *keygen.PublicKey {
+	t.Helper()
+
+	sshkey, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error creating key pair: %v", err)
+	}
+
+	key, err := keygen.ParseAuthorizedKey(sshkey.PublicKey())
+	if err != nil {
+		t.Fatalf("error parsing public key: %v", err)
+	}
+
+	return key
+}
+
+func testKnownHostsCallback(t *testing.T, knownHosts []byte) ssh.HostKeyCallback {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(file, knownHosts, 0o600); err != nil {
+		t.Fatalf("error writing known_hosts: %v", err)
+	}
+
+	callback, err := knownhosts.New(file)
+	if err != nil {
+		t.Fatalf("error reading known_hosts: %v", err)
+	}
+
+	return callback
+}
+
+func testTCPAddr(port int) net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("198.51.100.1"), Port: port}
+}
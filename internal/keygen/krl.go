@@ -0,0 +1,222 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// KRL format constants, see PROTOCOL.krl of OpenSSH.
+const (
+	krlMagic         uint64 = 0x5353484b524c0a00
+	krlFormatVersion uint32 = 1
+
+	krlSectionCertificates      byte = 1
+	krlSectionExplicitKey       byte = 2
+	krlSectionFingerprintSHA256 byte = 5
+
+	krlSectionCertSerialList  byte = 0x20
+	krlSectionCertSerialRange byte = 0x21
+	krlSectionCertKeyID       byte = 0x23
+)
+
+var (
+	// ErrKRLSerial indicates an invalid certificate serial or serial range.
+	ErrKRLSerial = errors.New("invalid certificate serial")
+	// ErrKRLMissingCA indicates serial revocations without CA key.
+	ErrKRLMissingCA = errors.New("revoking certificates by serial requires a CA key")
+	// ErrKRLFingerprint indicates a malformed SHA256 fingerprint.
+	ErrKRLFingerprint = errors.New("invalid SHA256 fingerprint")
+	// ErrKRLCertificate indicates a certificate passed as revoked key.
+	ErrKRLCertificate = errors.New("certificates cannot be revoked as keys, revoke their serial or key ID")
+)
+
+// KRLSerialRange is an inclusive range of certificate serials.
+type KRLSerialRange struct {
+	Min uint64
+	Max uint64
+}
+
+// KRLConfig holds the revocations of an OpenSSH key revocation list.
+type KRLConfig struct {
+	// Version of the KRL, which should increase with every change
+	Version uint64
+	// Comment stored in the KRL
+	Comment string
+	// GeneratedAt is the creation time of the KRL
+	GeneratedAt time.Time
+	// Keys revoked explicitly
+	Keys []*PublicKey
+	// SHA256Fingerprints of revoked keys, e.g. SHA256:...
+	SHA256Fingerprints []string
+	// CAKey issued the revoked certificates; nil means any CA, which is only
+	// supported for key IDs
+	CAKey *PublicKey
+	// Serials of revoked certificates
+	Serials []uint64
+	// SerialRanges of revoked certificates
+	SerialRanges []KRLSerialRange
+	// KeyIDs of revoked certificates
+	KeyIDs []string
+}
+
+// MarshalKRL returns the binary KRL as read by sshd RevokedKeys and
+// ssh-keygen -Q.
+func MarshalKRL(conf *KRLConfig) ([]byte, error) {
+	var out bytes.Buffer
+
+	out.Write(binary.BigEndian.AppendUint64(nil, krlMagic))
+	out.Write(binary.BigEndian.AppendUint32(nil, krlFormatVersion))
+	out.Write(binary.BigEndian.AppendUint64(nil, conf.Version))
+	out.Write(binary.BigEndian.AppendUint64(nil, uint64(conf.GeneratedAt.Unix()))) //nolint:gosec
+	out.Write(binary.BigEndian.AppendUint64(nil, 0))
+	out.Write(krlString(nil))
+	out.Write(krlString([]byte(conf.Comment)))
+
+	certs, err := conf.certificateSection()
+	if err != nil {
+		return nil, err
+	}
+
+	if certs != nil {
+		out.Write(krlSection(krlSectionCertificates, certs))
+	}
+
+	var keys [][]byte
+
+	for _, key := range conf.Keys {
+		if _, ok := key.Key.(*ssh.Certificate); ok {
+			return nil, ErrKRLCertificate
+		}
+
+		keys = append(keys, key.Key.Marshal())
+	}
+
+	if len(keys) > 0 {
+		out.Write(krlSection(krlSectionExplicitKey, krlStrings(keys)))
+	}
+
+	var hashes [][]byte
+
+	for _, fingerprint := range conf.SHA256Fingerprints {
+		hash, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(fingerprint, "SHA256:"))
+		if err != nil || len(hash) != 32 || !strings.HasPrefix(fingerprint, "SHA256:") {
+			return nil, fmt.Errorf("%w: %s", ErrKRLFingerprint, fingerprint)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if len(hashes) > 0 {
+		out.Write(krlSection(krlSectionFingerprintSHA256, krlStrings(hashes)))
+	}
+
+	return out.Bytes(), nil
+}
+
+// certificateSection returns the certificate revocations, or nil without
+// certificate revocations.
+func (conf *KRLConfig) certificateSection() ([]byte, error) {
+	if len(conf.Serials) == 0 && len(conf.SerialRanges) == 0 && len(conf.KeyIDs) == 0 {
+		return nil, nil
+	}
+
+	if conf.CAKey == nil && (len(conf.Serials) > 0 || len(conf.SerialRanges) > 0) {
+		return nil, ErrKRLMissingCA
+	}
+
+	var section bytes.Buffer
+
+	if conf.CAKey != nil {
+		section.Write(krlString(conf.CAKey.Key.Marshal()))
+	} else {
+		section.Write(krlString(nil))
+	}
+
+	section.Write(krlString(nil))
+
+	if len(conf.Serials) > 0 {
+		serials := slices.Compact(slices.Sorted(slices.Values(conf.Serials)))
+		if serials[0] == 0 {
+			return nil, fmt.Errorf("%w: serial 0 cannot be revoked", ErrKRLSerial)
+		}
+
+		var list []byte
+		for _, serial := range serials {
+			list = binary.BigEndian.AppendUint64(list, serial)
+		}
+
+		section.Write(krlSection(krlSectionCertSerialList, list))
+	}
+
+	ranges := slices.SortedFunc(slices.Values(conf.SerialRanges), func(a, b KRLSerialRange) int {
+		return cmp.Or(cmp.Compare(a.Min, b.Min), cmp.Compare(a.Max, b.Max))
+	})
+
+	for _, serialRange := range slices.Compact(ranges) {
+		if serialRange.Min == 0 || serialRange.Min > serialRange.Max {
+			return nil, fmt.Errorf("%w: range %d-%d", ErrKRLSerial, serialRange.Min, serialRange.Max)
+		}
+
+		data := binary.BigEndian.AppendUint64(nil, serialRange.Min)
+		data = binary.BigEndian.AppendUint64(data, serialRange.Max)
+
+		section.Write(krlSection(krlSectionCertSerialRange, data))
+	}
+
+	if len(conf.KeyIDs) > 0 {
+		var ids [][]byte
+		for _, id := range slices.Compact(slices.Sorted(slices.Values(conf.KeyIDs))) {
+			ids = append(ids, []byte(id))
+		}
+
+		section.Write(krlSection(krlSectionCertKeyID, krlStrings(ids)))
+	}
+
+	return section.Bytes(), nil
+}
+
+// krlSection encodes a typed KRL section.
+func krlSection(sectionType byte, data []byte) []byte {
+	return append([]byte{sectionType}, krlString(data)...)
+}
+
+// krlString encodes an SSH string, i.e. a length prefixed byte slice.
+func krlString(data []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(data))), data...) //nolint:gosec
+}
+
+// krlStrings encodes sorted, de-duplicated SSH strings like ssh-keygen.
+func krlStrings(values [][]byte) []byte {
+	slices.SortFunc(values, bytes.Compare)
+
+	var out []byte
+	for _, value := range slices.CompactFunc(values, bytes.Equal) {
+		out = append(out, krlString(value)...)
+	}
+
+	return out
+}
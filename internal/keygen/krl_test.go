@@ -0,0 +1,154 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestMarshalKRL(t *testing.T) {
+	t.Parallel()
+
+	revoked := testPublicKey(t)
+	ca := testPublicKey(t)
+	generatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	krl, err := keygen.MarshalKRL(&keygen.KRLConfig{
+		Version:            3,
+		Comment:            "leaked keys",
+		GeneratedAt:        generatedAt,
+		Keys:               []*keygen.PublicKey{revoked, revoked},
+		SHA256Fingerprints: []string{ca.SHA256()},
+		CAKey:              ca,
+		Serials:            []uint64{5, 1, 5},
+		SerialRanges:       []keygen.KRLSerialRange{{Min: 100, Max: 200}},
+		KeyIDs:             []string{"jane"},
+	})
+	if err != nil {
+		t.Fatalf("error creating KRL: %v", err)
+	}
+
+	if !bytes.HasPrefix(krl, []byte("SSHKRL\n\x00\x00\x00\x00\x01")) {
+		t.Fatalf("unexpected KRL header %q", krl[:12])
+	}
+
+	r := bytes.NewReader(krl[12:])
+
+	var version, date, flags uint64
+	for _, v := range []*uint64{&version, &date, &flags} {
+		_ = binary.Read(r, binary.BigEndian, v)
+	}
+
+	if version != 3 || date != uint64(generatedAt.Unix()) || flags != 0 {
+		t.Errorf("unexpected version %d, date %d or flags %d", version, date, flags)
+	}
+
+	if reserved := testReadString(t, r); len(reserved) != 0 {
+		t.Errorf("unexpected reserved %q", reserved)
+	}
+
+	if comment := testReadString(t, r); string(comment) != "leaked keys" {
+		t.Errorf("unexpected comment %q", comment)
+	}
+
+	sections := map[byte][]byte{}
+
+	var types []byte
+
+	for r.Len() > 0 {
+		sectionType, _ := r.ReadByte()
+		types = append(types, sectionType)
+		sections[sectionType] = testReadString(t, r)
+	}
+
+	if !slices.Equal(types, []byte{1, 2, 5}) {
+		t.Fatalf("unexpected sections %v", types)
+	}
+
+	keys := bytes.NewReader(sections[2])
+	if key := testReadString(t, keys); !bytes.Equal(key, revoked.Key.Marshal()) || keys.Len() != 0 {
+		t.Errorf("expected the revoked key exactly once")
+	}
+
+	certs := bytes.NewReader(sections[1])
+	if caKey := testReadString(t, certs); !bytes.Equal(caKey, ca.Key.Marshal()) {
+		t.Errorf("unexpected CA key")
+	}
+
+	testReadString(t, certs)
+
+	var certTypes []byte
+
+	for certs.Len() > 0 {
+		certType, _ := certs.ReadByte()
+		certTypes = append(certTypes, certType)
+		data := testReadString(t, certs)
+
+		if certType == 0x20 && !bytes.Equal(data, []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 5}) {
+			t.Errorf("unexpected serial list %v", data)
+		}
+	}
+
+	if !slices.Equal(certTypes, []byte{0x20, 0x21, 0x23}) {
+		t.Errorf("unexpected certificate sections %v", certTypes)
+	}
+}
+
+func TestMarshalKRLInvalid(t *testing.T) {
+	t.Parallel()
+
+	key := testPublicKey(t)
+
+	for name, test := range map[string]struct {
+		conf keygen.KRLConfig
+		err  error
+	}{
+		"serial without CA": {conf: keygen.KRLConfig{Serials: []uint64{1}}, err: keygen.ErrKRLMissingCA},
+		"serial 0":          {conf: keygen.KRLConfig{CAKey: key, Serials: []uint64{0}}, err: keygen.ErrKRLSerial},
+		"reversed range": {
+			conf: keygen.KRLConfig{CAKey: key, SerialRanges: []keygen.KRLSerialRange{{Min: 5, Max: 1}}},
+			err:  keygen.ErrKRLSerial,
+		},
+		"fingerprint": {conf: keygen.KRLConfig{SHA256Fingerprints: []string{key.MD5()}}, err: keygen.ErrKRLFingerprint},
+	} {
+		if _, err := keygen.MarshalKRL(&test.conf); !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", name, test.err, err)
+		}
+	}
+}
+
+func testReadString(t *testing.T, r *bytes.Reader) []byte {
+	t.Helper()
+
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		t.Fatalf("error reading string length: %v", err)
+	}
+
+	data := make([]byte, length)
+	if _, err := r.Read(data); err != nil && length > 0 {
+		t.Fatalf("error reading string: %v", err)
+	}
+
+	return data
+}
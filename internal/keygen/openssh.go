@@ -0,0 +1,284 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/bcryptpbkdf"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	openSSHMagic     = "openssh-key-v1\x00"
+	openSSHBlockType = "OPENSSH PRIVATE KEY"
+	openSSHSaltSize  = 16
+	openSSHKeySize   = 32
+
+	// OpenSSHDefaultKDFRounds is the bcrypt_pbkdf rounds count ssh-keygen uses
+	// to encrypt private keys.
+	OpenSSHDefaultKDFRounds = 16
+)
+
+// ErrInvalidOpenSSHKey indicates a malformed OPENSSH PRIVATE KEY block.
+var ErrInvalidOpenSSHKey = errors.New("invalid openssh private key")
+
+// openSSHKey is the container of an OPENSSH PRIVATE KEY block, see
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key.
+type openSSHKey struct {
+	CipherName   string
+	KdfName      string
+	KdfOpts      string
+	NumKeys      uint32
+	PubKey       []byte
+	PrivKeyBlock []byte
+}
+
+// openSSHPrivateKey is the decrypted private section of the container.
+type openSSHPrivateKey struct {
+	Check1  uint32
+	Check2  uint32
+	Keytype string
+	Rest    []byte `ssh:"rest"`
+}
+
+// openSSHKDFOptions are the options of the bcrypt KDF.
+type openSSHKDFOptions struct {
+	Salt   string
+	Rounds uint32
+}
+
+// decryptOpenSSH returns the unencrypted OPENSSH PRIVATE KEY block together
+// with its comment. The key is derived only once, as bcrypt_pbkdf is slow by
+// design. Like ssh.ParseRawPrivateKey, it returns an ssh.PassphraseMissingError
+// for encrypted keys without passphrase and x509.IncorrectPasswordError for a
+// wrong passphrase.
+func decryptOpenSSH(block *pem.Block, passphrase []byte) (*pem.Block, string, error) {
+	key, err := parseOpenSSHKey(block)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if key.CipherName != "none" && len(passphrase) == 0 {
+		pub, err := ssh.ParsePublicKey(key.PubKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrInvalidOpenSSHKey, err)
+		}
+
+		return nil, "", &ssh.PassphraseMissingError{PublicKey: pub}
+	}
+
+	privKeyBlock, err := key.decrypt(passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+
+	comment, _, err := splitOpenSSHPrivateSection(privKeyBlock)
+
+	switch {
+	case errors.Is(err, ErrInvalidOpenSSHKey) && key.CipherName != "none":
+		// A wrong passphrase yields a garbled private section.
+		return nil, "", x509.IncorrectPasswordError
+	case err != nil:
+		return nil, "", err
+	}
+
+	key.CipherName = "none"
+	key.KdfName = "none"
+	key.KdfOpts = ""
+	key.PrivKeyBlock = privKeyBlock
+
+	return &pem.Block{
+		Type:  openSSHBlockType,
+		Bytes: append([]byte(openSSHMagic), ssh.Marshal(key)...),
+	}, string(comment), nil
+}
+
+// EncryptedOpenSSH returns the re-armored OPENSSH PRIVATE KEY block of an
+// encrypted private key, or nil for unencrypted keys and other formats.
+func EncryptedOpenSSH(pemBytes []byte) []byte {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != openSSHBlockType {
+		return nil
+	}
+
+	key, err := parseOpenSSHKey(block)
+	if err != nil || key.CipherName == "none" {
+		return nil
+	}
+
+	return pem.EncodeToMemory(block)
+}
+
+// encryptOpenSSH encrypts an unencrypted OPENSSH PRIVATE KEY block the way
+// ssh-keygen does, with aes256-ctr and a key derived by bcrypt_pbkdf with the
+// given number of rounds.
+func encryptOpenSSH(block *pem.Block, passphrase []byte, rounds int) (*pem.Block, error) {
+	key, err := parseOpenSSHKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.CipherName != "none" {
+		return nil, fmt.Errorf("%w: key is already encrypted", ErrInvalidOpenSSHKey)
+	}
+
+	_, padding, err := splitOpenSSHPrivateSection(key.PrivKeyBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-pad the private section to the cipher block size.
+	privKeyBlock := bytes.Clone(key.PrivKeyBlock[:len(key.PrivKeyBlock)-len(padding)])
+	for i := 1; len(privKeyBlock)%aes.BlockSize != 0; i++ {
+		privKeyBlock = append(privKeyBlock, byte(i))
+	}
+
+	salt := make([]byte, openSSHSaltSize)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	derived, err := bcryptpbkdf.Key(passphrase, salt, rounds, openSSHKeySize+aes.BlockSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	cipherBlock, err := aes.NewCipher(derived[:openSSHKeySize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	cipher.NewCTR(cipherBlock, derived[openSSHKeySize:]).XORKeyStream(privKeyBlock, privKeyBlock)
+
+	key.CipherName = "aes256-ctr"
+	key.KdfName = "bcrypt"
+	key.KdfOpts = string(ssh.Marshal(openSSHKDFOptions{Salt: string(salt), Rounds: uint32(rounds)})) //nolint:gosec
+	key.PrivKeyBlock = privKeyBlock
+
+	return &pem.Block{
+		Type:  openSSHBlockType,
+		Bytes: append([]byte(openSSHMagic), ssh.Marshal(key)...),
+	}, nil
+}
+
+// parseOpenSSHKey parses the container of an OPENSSH PRIVATE KEY block.
+func parseOpenSSHKey(block *pem.Block) (*openSSHKey, error) {
+	if !bytes.HasPrefix(block.Bytes, []byte(openSSHMagic)) {
+		return nil, ErrInvalidOpenSSHKey
+	}
+
+	var key openSSHKey
+	if err := ssh.Unmarshal(block.Bytes[len(openSSHMagic):], &key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOpenSSHKey, err)
+	}
+
+	return &key, nil
+}
+
+// splitOpenSSHPrivateSection returns the comment and the trailing padding of
+// a decrypted private section.
+func splitOpenSSHPrivateSection(privKeyBlock []byte) ([]byte, []byte, error) {
+	var pk openSSHPrivateKey
+	if err := ssh.Unmarshal(privKeyBlock, &pk); err != nil || pk.Check1 != pk.Check2 {
+		return nil, nil, ErrInvalidOpenSSHKey
+	}
+
+	impl, ok := keyTypeOfAlgorithm(pk.Keytype)
+	if !ok || impl.OpenSSHKeyFields == 0 {
+		return nil, nil, UnsupportedKeyTypeError{pk.Keytype}
+	}
+
+	var comment []byte
+
+	rest := pk.Rest
+	for range impl.OpenSSHKeyFields + 1 {
+		if comment, rest, ok = parseString(rest); !ok {
+			return nil, nil, ErrInvalidOpenSSHKey
+		}
+	}
+
+	return comment, rest, nil
+}
+
+// decrypt returns the private section of the container. The section is
+// decrypted with the passphrase if required.
+func (k *openSSHKey) decrypt(passphrase []byte) ([]byte, error) {
+	if k.CipherName == "none" {
+		return k.PrivKeyBlock, nil
+	}
+
+	if k.KdfName != "bcrypt" {
+		return nil, fmt.Errorf("%w: unsupported KDF %q", ErrInvalidOpenSSHKey, k.KdfName)
+	}
+
+	var opts openSSHKDFOptions
+	if err := ssh.Unmarshal([]byte(k.KdfOpts), &opts); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOpenSSHKey, err)
+	}
+
+	derived, err := bcryptpbkdf.Key(passphrase, []byte(opts.Salt), int(opts.Rounds), 32+aes.BlockSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(derived[:32])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	iv := derived[32:]
+	privKeyBlock := bytes.Clone(k.PrivKeyBlock)
+
+	switch k.CipherName {
+	case "aes256-ctr":
+		cipher.NewCTR(block, iv).XORKeyStream(privKeyBlock, privKeyBlock)
+	case "aes256-cbc":
+		if len(privKeyBlock)%aes.BlockSize != 0 {
+			return nil, ErrInvalidOpenSSHKey
+		}
+
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(privKeyBlock, privKeyBlock)
+	default:
+		return nil, fmt.Errorf("%w: unsupported cipher %q", ErrInvalidOpenSSHKey, k.CipherName)
+	}
+
+	return privKeyBlock, nil
+}
+
+// parseString reads a length prefixed string as defined in RFC 4251.
+func parseString(in []byte) ([]byte, []byte, bool) {
+	if len(in) < 4 {
+		return nil, nil, false
+	}
+
+	length := binary.BigEndian.Uint32(in)
+	in = in[4:]
+
+	if uint32(len(in)) < length { //nolint:gosec
+		return nil, nil, false
+	}
+
+	return in[:length], in[length:], true
+}
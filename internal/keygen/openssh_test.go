@@ -0,0 +1,120 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPrivateKeyKDFRounds(t *testing.T) {
+	t.Parallel()
+
+	for _, rounds := range []int{0, 1, 64} {
+		for _, keyType := range keygen.KeyTypes() {
+			key, err := keygen.New(&keygen.SSHKeyPairConfig{
+				Type:       keyType,
+				Bits:       2048,
+				Comment:    "user@example.com",
+				Passphrase: []byte("test"),
+				KDFRounds:  rounds,
+			})
+			if err != nil {
+				t.Fatalf("error creating SSH key pair: %v", err)
+			}
+
+			expected := rounds
+			if expected == 0 {
+				expected = keygen.OpenSSHDefaultKDFRounds
+			}
+
+			if got := openSSHKDFRounds(t, key.PrivateKeyPEM()); got != expected {
+				t.Errorf("%s: expected %d rounds, got %d", keyType, expected, got)
+			}
+
+			if _, err = ssh.ParseRawPrivateKeyWithPassphrase(key.PrivateKeyPEM(), []byte("test")); err != nil {
+				t.Errorf("%s: error decrypting private key: %v", keyType, err)
+			}
+
+			parsed, err := keygen.Parse(key.PrivateKeyPEM(), []byte("test"))
+			if err != nil {
+				t.Fatalf("%s: error reading SSH key pair: %v", keyType, err)
+			}
+
+			if parsed.Comment != "user@example.com" {
+				t.Errorf("%s: unexpected comment %q", keyType, parsed.Comment)
+			}
+		}
+	}
+}
+
+func TestEncryptedOpenSSH(t *testing.T) {
+	t.Parallel()
+
+	encrypted, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519, Passphrase: []byte("test")})
+	if err != nil {
+		t.Fatalf("error creating SSH key pair: %v", err)
+	}
+
+	plain, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error creating SSH key pair: %v", err)
+	}
+
+	// Every call encrypts the private key with a new salt.
+	pemBytes := encrypted.PrivateKeyPEM()
+
+	if got := keygen.EncryptedOpenSSH(append([]byte("\n"), pemBytes...)); string(got) != string(pemBytes) {
+		t.Errorf("expected the encrypted private key, got %q", got)
+	}
+
+	if got := keygen.EncryptedOpenSSH(plain.PrivateKeyPEM()); got != nil {
+		t.Errorf("expected nil for an unencrypted private key, got %q", got)
+	}
+}
+
+// openSSHKDFRounds returns the bcrypt_pbkdf rounds of an OPENSSH PRIVATE KEY.
+func openSSHKDFRounds(t *testing.T, pemBytes []byte) int {
+	t.Helper()
+
+	block, _ := pem.Decode(pemBytes)
+
+	var key struct {
+		CipherName string
+		KdfName    string
+		KdfOpts    string
+		Rest       []byte `ssh:"rest"`
+	}
+
+	if err := ssh.Unmarshal([]byte(strings.TrimPrefix(string(block.Bytes), "openssh-key-v1\x00")), &key); err != nil {
+		t.Fatalf("error reading private key: %v", err)
+	}
+
+	var opts struct {
+		Salt   string
+		Rounds uint32
+	}
+
+	if err := ssh.Unmarshal([]byte(key.KdfOpts), &opts); err != nil {
+		t.Fatalf("error reading KDF options: %v", err)
+	}
+
+	return int(opts.Rounds)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// PEM block types of the supported private key encodings.
+const (
+	pemTypePKCS1          = "RSA PRIVATE KEY"
+	pemTypeSEC1           = "EC PRIVATE KEY"
+	pemTypePKCS8          = "PRIVATE KEY"
+	pemTypeEncryptedPKCS8 = "ENCRYPTED PRIVATE KEY"
+)
+
+// PrivateKeyOpenSSH returns the private key in OPENSSH PEM format, just like
+// PrivateKeyPEM.
+func (s *SSHKeyPair) PrivateKeyOpenSSH() []byte {
+	return s.PrivateKeyPEM()
+}
+
+// PrivateKeyPKCS8PEM returns the private key in PKCS#8 PEM format. The key is
+// encrypted with PBES2 when a passphrase is set.
+func (s *SSHKeyPair) PrivateKeyPKCS8PEM() []byte {
+	key := s.PrivateKey()
+	if edKey, ok := key.(*ed25519.PrivateKey); ok {
+		key = *edKey
+	}
+
+	if key == nil {
+		return nil
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil
+	}
+
+	if len(s.Passphrase) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: der})
+	}
+
+	der, err = encryptPKCS8(der, s.Passphrase)
+	if err != nil {
+		return nil
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPKCS8, Bytes: der})
+}
+
+// PrivateKeyTraditionalPEM returns the private key in the traditional PEM
+// format of OpenSSL, i.e. PKCS#1 for RSA and SEC1 for ECDSA keys. There is no
+// such format for ED25519 keys, so nil is returned for them. The legacy PEM
+// encryption is insecure, so nil is returned as well when a passphrase is set.
+// Use PrivateKeyPKCS8PEM for encrypted keys instead.
+func (s *SSHKeyPair) PrivateKeyTraditionalPEM() []byte {
+	if len(s.Passphrase) > 0 {
+		return nil
+	}
+
+	impl, ok := LookupKeyType(s.Type)
+	if !ok || impl.MarshalTraditional == nil || s.PrivateKeyRaw == nil {
+		return nil
+	}
+
+	block, err := impl.MarshalTraditional(s.PrivateKeyRaw)
+	if err != nil {
+		return nil
+	}
+
+	return pem.EncodeToMemory(block)
+}
+
+// marshalTraditionalRSAKey encodes a RSA private key in PKCS#1 format.
+func marshalTraditionalRSAKey(key crypto.PrivateKey) (*pem.Block, error) {
+	rsaKey, err := keyAs[*rsa.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pem.Block{Type: pemTypePKCS1, Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}, nil
+}
+
+// marshalTraditionalECDSAKey encodes an ECDSA private key in SEC1 format.
+func marshalTraditionalECDSAKey(key crypto.PrivateKey) (*pem.Block, error) {
+	ecKey, err := keyAs[*ecdsa.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return &pem.Block{Type: pemTypeSEC1, Bytes: der}, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"encoding/pem"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestPrivateKeyEncodings(t *testing.T) {
+	t.Parallel()
+
+	for _, passphrase := range []string{"", "secret"} {
+		for _, keyType := range keygen.KeyTypes() {
+			key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Passphrase: []byte(passphrase)})
+			if err != nil {
+				t.Fatalf("error creating SSH key pair: %v", err)
+			}
+
+			encodings := map[string][]byte{
+				"OPENSSH PRIVATE KEY": key.PrivateKeyOpenSSH(),
+				"PRIVATE KEY":         key.PrivateKeyPKCS8PEM(),
+			}
+
+			switch {
+			case passphrase != "" || keyType == keygen.ED25519:
+				if key.PrivateKeyTraditionalPEM() != nil {
+					t.Errorf("%s: expected no traditional PEM encoding", keyType)
+				}
+			case keyType == keygen.RSA:
+				encodings["RSA PRIVATE KEY"] = key.PrivateKeyTraditionalPEM()
+			case keyType == keygen.ECDSA:
+				encodings["EC PRIVATE KEY"] = key.PrivateKeyTraditionalPEM()
+			}
+
+			for blockType, encoded := range encodings {
+				block, _ := pem.Decode(encoded)
+				if block == nil {
+					t.Fatalf("%s: no %s PEM block", keyType, blockType)
+				}
+
+				if passphrase != "" && blockType == "PRIVATE KEY" {
+					blockType = "ENCRYPTED PRIVATE KEY"
+				}
+
+				if block.Type != blockType {
+					t.Errorf("%s: expected %s PEM block, got %s", keyType, blockType, block.Type)
+				}
+
+				parsed, err := keygen.Parse(encoded, []byte(passphrase))
+				if err != nil {
+					t.Fatalf("%s: error reading %s: %v", keyType, blockType, err)
+				}
+
+				if parsed.SHA256() != key.SHA256() {
+					t.Errorf("%s: fingerprint mismatch after parsing %s", keyType, blockType)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// PublicKey holds a parsed OpenSSH public key.
+type PublicKey struct {
+	Key     ssh.PublicKey
+	Comment string
+	Options []string
+}
+
+// ParseAuthorizedKey parses a public key in authorized_keys format, including
+// an optional options prefix.
+func ParseAuthorizedKey(in []byte) (*PublicKey, error) {
+	key, comment, options, _, err := ssh.ParseAuthorizedKey(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	return &PublicKey{Key: key, Comment: comment, Options: options}, nil
+}
+
+// ParsePrivateKeyPublicKey returns the public key of a PEM encoded private key
+// together with its comment. The public key of encrypted OpenSSH keys is
+// readable without passphrase, but their comment is not.
+func ParsePrivateKeyPublicKey(pemBytes, passphrase []byte) (*PublicKey, error) {
+	sshkey, err := Parse(pemBytes, passphrase)
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) && missing.PublicKey != nil {
+		return &PublicKey{Key: missing.PublicKey}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ssh.NewPublicKey(sshkey.publicKeyRaw())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	return &PublicKey{Key: key, Comment: sshkey.Comment}, nil
+}
+
+// Algorithm returns the SSH algorithm name of the key, e.g. ssh-ed25519.
+func (p *PublicKey) Algorithm() string {
+	return p.Key.Type()
+}
+
+// Type returns the key type, or an empty KeyType for algorithms which are not
+// registered.
+func (p *PublicKey) Type() KeyType {
+	alg, _ := LookupKeyAlgorithm(p.Key.Type())
+
+	return alg.Type
+}
+
+// SecurityKey reports whether the key belongs to a FIDO security key.
+func (p *PublicKey) SecurityKey() bool {
+	alg, _ := LookupKeyAlgorithm(p.Key.Type())
+
+	return alg.SecurityKey
+}
+
+// Bits returns the size of the key in bits.
+func (p *PublicKey) Bits() int {
+	cryptoKey, ok := p.Key.(ssh.CryptoPublicKey)
+	if !ok {
+		return 0
+	}
+
+	impl, ok := keyTypeOfAlgorithm(p.Key.Type())
+	if !ok || impl.PublicKeyBits == nil {
+		return 0
+	}
+
+	return impl.PublicKeyBits(cryptoKey.CryptoPublicKey())
+}
+
+// Curve returns the curve of ECDSA keys, or an empty Curve for other keys.
+func (p *PublicKey) Curve() Curve {
+	cryptoKey, ok := p.Key.(ssh.CryptoPublicKey)
+	if !ok {
+		return ""
+	}
+
+	key, ok := cryptoKey.CryptoPublicKey().(*ecdsa.PublicKey)
+	if !ok {
+		return ""
+	}
+
+	return curveOf(key.Curve)
+}
+
+// Marshal returns the normalized public key without options, ready to be used
+// in an OpenSSH authorized_keys file.
+func (p *PublicKey) Marshal() []byte {
+	ak := ssh.MarshalAuthorizedKey(p.Key)
+
+	return bytes.TrimSpace(fmt.Appendf(bytes.TrimSpace(ak), " %s", p.Comment))
+}
+
+// MD5 returns the legacy MD5 fingerprint of the key.
+func (p *PublicKey) MD5() string {
+	return ssh.FingerprintLegacyMD5(p.Key)
+}
+
+// SHA256 returns the SHA256 fingerprint of the key.
+func (p *PublicKey) SHA256() string {
+	return ssh.FingerprintSHA256(p.Key)
+}
+
+// bitsRSAKey returns the size of RSA public keys.
+func bitsRSAKey(pub crypto.PublicKey) int {
+	rsaKey, err := keyAs[*rsa.PublicKey](pub)
+	if err != nil {
+		return 0
+	}
+
+	return rsaKey.N.BitLen()
+}
+
+// bitsED25519Key returns the fixed size of Ed25519 public keys.
+func bitsED25519Key(crypto.PublicKey) int {
+	return ed25519BitSize
+}
+
+// bitsECDSAKey returns the size of ECDSA public keys, i.e. of their curve.
+func bitsECDSAKey(pub crypto.PublicKey) int {
+	ecKey, err := keyAs[*ecdsa.PublicKey](pub)
+	if err != nil {
+		return 0
+	}
+
+	return ecKey.Curve.Params().BitSize
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestParseAuthorizedKey(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 2048, Comment: "user@example.com"})
+		if err != nil {
+			t.Fatalf("error creating SSH key pair: %v", err)
+		}
+
+		line := append([]byte(`no-pty,command="/bin/true" `), key.PublicKey()...)
+
+		pubKey, err := keygen.ParseAuthorizedKey(line)
+		if err != nil {
+			t.Fatalf("%s: error parsing public key: %v", keyType, err)
+		}
+
+		switch {
+		case pubKey.Type() != keyType:
+			t.Errorf("%s: unexpected type %q", keyType, pubKey.Type())
+		case pubKey.Bits() != int(key.Bits):
+			t.Errorf("%s: unexpected bits %d, expected %d", keyType, pubKey.Bits(), key.Bits)
+		case pubKey.Curve() != key.Curve:
+			t.Errorf("%s: unexpected curve %q", keyType, pubKey.Curve())
+		case pubKey.Comment != "user@example.com":
+			t.Errorf("%s: unexpected comment %q", keyType, pubKey.Comment)
+		case len(pubKey.Options) != 2:
+			t.Errorf("%s: unexpected options %v", keyType, pubKey.Options)
+		case string(pubKey.Marshal()) != string(key.PublicKey()):
+			t.Errorf("%s: unexpected normalized key %q", keyType, pubKey.Marshal())
+		case pubKey.SHA256() != key.SHA256() || pubKey.MD5() != key.MD5():
+			t.Errorf("%s: fingerprint mismatch", keyType)
+		}
+	}
+}
+
+func TestParseAuthorizedKeyInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := keygen.ParseAuthorizedKey([]byte("ssh-ed25519 invalid")); err == nil {
+		t.Error("expected error parsing invalid public key")
+	}
+}
+
+// FIDO security keys can not be generated without hardware.
+const (
+	testSKEd25519PublicKey = "sk-ssh-ed25519@openssh.com " +
+		"AAAAGnNrLXNzaC1lZDI1NTE5QG9wZW5zc2guY29tAAAAIDWzoLKY67AUyM60gIf+mSwtrqVLvZMHpjJjhuKOVArVAAAABHNzaDo= " +
+		"jane@example.com"
+	testSKECDSAPublicKey = "sk-ecdsa-sha2-nistp256@openssh.com " +
+		"AAAAInNrLWVjZHNhLXNoYTItbmlzdHAyNTZAb3BlbnNzaC5jb20AAAAIbmlzdHAyNTYAAABBBKWjNSsrb/p2So8F1xT5Sc8am+0HXYOplFp6i26b" +
+		"1Su5jpVwN2J9oV7MMD69ddfp/W5ojT+pizlFQYT2WV4sFh0AAAAEc3NoOg== jane@example.com"
+)
+
+func TestParsePrivateKeyPublicKey(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		for _, passphrase := range []string{"", "test"} {
+			key, err := keygen.New(&keygen.SSHKeyPairConfig{
+				Type:       keyType,
+				Bits:       2048,
+				Comment:    "user@example.com",
+				Passphrase: []byte(passphrase),
+			})
+			if err != nil {
+				t.Fatalf("error creating SSH key pair: %v", err)
+			}
+
+			// Encrypted keys reveal their public key, but not their comment.
+			pubKey, err := keygen.ParsePrivateKeyPublicKey(key.PrivateKeyPEM(), nil)
+			if err != nil {
+				t.Fatalf("%s: error reading public key: %v", keyType, err)
+			}
+
+			if pubKey.SHA256() != key.SHA256() {
+				t.Errorf("%s: fingerprint mismatch", keyType)
+			}
+
+			if passphrase == "" && string(pubKey.Marshal()) != string(key.PublicKey()) {
+				t.Errorf("%s: unexpected public key %q", keyType, pubKey.Marshal())
+			}
+
+			if passphrase != "" && pubKey.Comment != "" {
+				t.Errorf("%s: unexpected comment %q of encrypted key", keyType, pubKey.Comment)
+			}
+		}
+	}
+
+	if _, err := keygen.ParsePrivateKeyPublicKey([]byte("invalid"), nil); err == nil {
+		t.Error("expected error reading invalid private key")
+	}
+}
+
+func TestParseSecurityKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		publicKey string
+		keyType   keygen.KeyType
+		curve     keygen.Curve
+	}{
+		{testSKEd25519PublicKey, keygen.ED25519SK, ""},
+		{testSKECDSAPublicKey, keygen.ECDSASK, keygen.P256},
+	}
+
+	for _, test := range tests {
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(test.publicKey))
+		if err != nil {
+			t.Fatalf("%s: error parsing public key: %v", test.keyType, err)
+		}
+
+		switch {
+		case pubKey.Type() != test.keyType:
+			t.Errorf("%s: unexpected type %q", test.keyType, pubKey.Type())
+		case !pubKey.SecurityKey():
+			t.Errorf("%s: not recognized as security key", test.keyType)
+		case pubKey.Bits() != 256:
+			t.Errorf("%s: unexpected bits %d", test.keyType, pubKey.Bits())
+		case pubKey.Curve() != test.curve:
+			t.Errorf("%s: unexpected curve %q", test.keyType, pubKey.Curve())
+		case string(pubKey.Marshal()) != test.publicKey:
+			t.Errorf("%s: unexpected normalized key %q", test.keyType, pubKey.Marshal())
+		}
+
+		if _, err = pubKey.JWK(); err == nil {
+			t.Errorf("%s: expected error creating JWK of a security key", test.keyType)
+		}
+	}
+}
+
+func TestKeyAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	for _, alg := range keygen.KeyAlgorithms() {
+		found, ok := keygen.LookupKeyAlgorithm(alg.Name)
+		if !ok || found != alg {
+			t.Errorf("%s: lookup returned %v", alg.Name, found)
+		}
+	}
+
+	if _, ok := keygen.LookupKeyAlgorithm("ssh-dss"); ok {
+		t.Error("unexpected ssh-dss algorithm")
+	}
+
+	// Security key algorithms are registered with their key type, which
+	// cannot be generated.
+	if alg, _ := keygen.LookupKeyAlgorithm("sk-ssh-ed25519@openssh.com"); alg.Type != keygen.ED25519SK {
+		t.Errorf("unexpected key type %q of sk-ssh-ed25519@openssh.com", alg.Type)
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/ssh"
+)
+
+// PPKVersion is the version of the PuTTY private key file format.
+type PPKVersion int
+
+const (
+	// PPKVersion2 is understood by all PuTTY releases, but uses a weak key
+	// derivation for encrypted keys.
+	PPKVersion2 PPKVersion = 2
+	// PPKVersion3 uses Argon2 key derivation and needs PuTTY 0.75 or newer.
+	PPKVersion3 PPKVersion = 3
+	// PPKDefaultVersion is the default PuTTY private key file format version.
+	PPKDefaultVersion = PPKVersion3
+)
+
+//nolint:gochecknoglobals
+var (
+	// PPKVersions lists the supported PuTTY private key file format versions.
+	PPKVersions = []int64{int64(PPKVersion2), int64(PPKVersion3)}
+)
+
+// Argon2id parameters used for encrypted PPK version 3 files. puttygen
+// calibrates the number of passes, a fixed value keeps the output stable
+// across machines.
+const (
+	ppkArgon2Memory      = 8192
+	ppkArgon2Passes      = 13
+	ppkArgon2Parallelism = 1
+	ppkArgon2SaltSize    = 16
+)
+
+const (
+	ppkCipherKeySize = 32
+	ppkMACKeySize    = 32
+	ppkLineLength    = 64
+	ppkMACKeyPrefix  = "putty-private-key-file-mac-key"
+)
+
+// PrivateKeyPuTTY returns the private key in the PuTTY private key file
+// format, as used by PuTTY and WinSCP. The key is encrypted with AES-256-CBC
+// when a passphrase is set.
+func (s *SSHKeyPair) PrivateKeyPuTTY(version PPKVersion) []byte {
+	pub, err := ssh.NewPublicKey(s.publicKeyRaw())
+	if err != nil {
+		return nil
+	}
+
+	impl, ok := LookupKeyType(s.Type)
+	if !ok || impl.MarshalPuTTY == nil {
+		return nil
+	}
+
+	privBlob, err := impl.MarshalPuTTY(s.PrivateKeyRaw)
+	if err != nil {
+		return nil
+	}
+
+	encryption := "none"
+	if len(s.Passphrase) > 0 {
+		encryption = "aes256-cbc"
+		privBlob = puttyPad(privBlob)
+	}
+
+	comment := strings.TrimSpace(s.Comment)
+	pubBlob := pub.Marshal()
+
+	var (
+		out                   bytes.Buffer
+		cipherKey, iv, macKey []byte
+		mac                   func() hash.Hash
+	)
+
+	fmt.Fprintf(&out, "PuTTY-User-Key-File-%d: %s\n", version, pub.Type())
+	fmt.Fprintf(&out, "Encryption: %s\n", encryption)
+	fmt.Fprintf(&out, "Comment: %s\n", comment)
+	writePuTTYLines(&out, "Public-Lines", pubBlob)
+
+	switch version {
+	case PPKVersion2:
+		cipherKey, iv, macKey = puttyV2Keys(s.Passphrase)
+		mac = sha1.New
+	case PPKVersion3:
+		if len(s.Passphrase) > 0 {
+			salt := make([]byte, ppkArgon2SaltSize)
+			if _, err = rand.Read(salt); err != nil {
+				return nil
+			}
+
+			cipherKey, iv, macKey = puttyV3Keys(s.Passphrase, salt)
+
+			fmt.Fprintf(&out, "Key-Derivation: Argon2id\n")
+			fmt.Fprintf(&out, "Argon2-Memory: %d\n", ppkArgon2Memory)
+			fmt.Fprintf(&out, "Argon2-Passes: %d\n", ppkArgon2Passes)
+			fmt.Fprintf(&out, "Argon2-Parallelism: %d\n", ppkArgon2Parallelism)
+			fmt.Fprintf(&out, "Argon2-Salt: %x\n", salt)
+		}
+
+		mac = sha256.New
+	default:
+		return nil
+	}
+
+	macData := ssh.Marshal(struct {
+		Algorithm  string
+		Encryption string
+		Comment    string
+		PublicKey  []byte
+		PrivateKey []byte
+	}{pub.Type(), encryption, comment, pubBlob, privBlob})
+
+	macHash := hmac.New(mac, macKey)
+	macHash.Write(macData)
+
+	if len(s.Passphrase) > 0 {
+		block, err := aes.NewCipher(cipherKey)
+		if err != nil {
+			return nil
+		}
+
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(privBlob, privBlob)
+	}
+
+	writePuTTYLines(&out, "Private-Lines", privBlob)
+	fmt.Fprintf(&out, "Private-MAC: %s\n", hex.EncodeToString(macHash.Sum(nil)))
+
+	return out.Bytes()
+}
+
+// marshalPuTTYRSAKey returns the private part of RSA keys in PuTTY private
+// key files.
+func marshalPuTTYRSAKey(key crypto.PrivateKey) ([]byte, error) {
+	rsaKey, err := keyAs[*rsa.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsaKey.Primes) != 2 { //nolint:mnd
+		return nil, UnsupportedKeyTypeError{"multi-prime rsa"}
+	}
+
+	p, q := rsaKey.Primes[0], rsaKey.Primes[1]
+
+	return ssh.Marshal(struct {
+		D, P, Q, Iqmp *big.Int
+	}{rsaKey.D, p, q, new(big.Int).ModInverse(q, p)}), nil
+}
+
+// marshalPuTTYED25519Key returns the private part of Ed25519 keys in PuTTY
+// private key files.
+func marshalPuTTYED25519Key(key crypto.PrivateKey) ([]byte, error) {
+	edKey, err := keyAs[*ed25519.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.Marshal(struct{ Seed []byte }{edKey.Seed()}), nil
+}
+
+// marshalPuTTYECDSAKey returns the private part of ECDSA keys in PuTTY
+// private key files.
+func marshalPuTTYECDSAKey(key crypto.PrivateKey) ([]byte, error) {
+	ecKey, err := keyAs[*ecdsa.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	scalar, err := ecKey.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return ssh.Marshal(struct{ D *big.Int }{new(big.Int).SetBytes(scalar)}), nil
+}
+
+// puttyPad pads the private blob to the AES block size. Like PuTTY, the
+// padding is taken from the SHA-1 hash of the unpadded blob.
+func puttyPad(blob []byte) []byte {
+	padding := (aes.BlockSize - len(blob)%aes.BlockSize) % aes.BlockSize
+	sum := sha1.Sum(blob) //nolint:gosec
+
+	return append(blob, sum[:padding]...)
+}
+
+// puttyV2Keys derives the cipher key, iv and MAC key of PPK version 2 files.
+func puttyV2Keys(passphrase []byte) ([]byte, []byte, []byte) {
+	var cipherKey []byte
+
+	for i := range uint32(2) { //nolint:mnd
+		h := sha1.New() //nolint:gosec
+		_ = binary.Write(h, binary.BigEndian, i)
+		h.Write(passphrase)
+		cipherKey = h.Sum(cipherKey)
+	}
+
+	macKey := sha1.Sum(append([]byte(ppkMACKeyPrefix), passphrase...)) //nolint:gosec
+
+	return cipherKey[:ppkCipherKeySize], make([]byte, aes.BlockSize), macKey[:]
+}
+
+// puttyV3Keys derives the cipher key, iv and MAC key of encrypted PPK
+// version 3 files.
+func puttyV3Keys(passphrase, salt []byte) ([]byte, []byte, []byte) {
+	keys := argon2.IDKey(
+		passphrase, salt,
+		ppkArgon2Passes, ppkArgon2Memory, ppkArgon2Parallelism,
+		ppkCipherKeySize+aes.BlockSize+ppkMACKeySize,
+	)
+
+	return keys[:ppkCipherKeySize], keys[ppkCipherKeySize : ppkCipherKeySize+aes.BlockSize],
+		keys[ppkCipherKeySize+aes.BlockSize:]
+}
+
+// writePuTTYLines writes the base64 encoded blob wrapped at 64 characters,
+// preceded by the line count.
+func writePuTTYLines(out *bytes.Buffer, name string, blob []byte) {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	lines := (len(encoded) + ppkLineLength - 1) / ppkLineLength
+
+	fmt.Fprintf(out, "%s: %d\n", name, lines)
+
+	for len(encoded) > ppkLineLength {
+		out.WriteString(encoded[:ppkLineLength] + "\n")
+		encoded = encoded[ppkLineLength:]
+	}
+
+	out.WriteString(encoded + "\n")
+}
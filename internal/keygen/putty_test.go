@@ -0,0 +1,303 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPrivateKeyPuTTY(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []keygen.PPKVersion{keygen.PPKVersion2, keygen.PPKVersion3} {
+		for _, passphrase := range []string{"", "secret"} {
+			for _, keyType := range keygen.KeyTypes() {
+				key, err := keygen.New(&keygen.SSHKeyPairConfig{
+					Type:       keyType,
+					Bits:       2048,
+					Comment:    "test@example.com",
+					Passphrase: []byte(passphrase),
+				})
+				if err != nil {
+					t.Fatalf("error creating SSH key pair: %v", err)
+				}
+
+				pub, err := readPuTTYKey(t, key.PrivateKeyPuTTY(version), []byte(passphrase))
+				if err != nil {
+					t.Fatalf("PPK v%d %s: %v", version, keyType, err)
+				}
+
+				if ssh.FingerprintSHA256(pub) != key.SHA256() {
+					t.Errorf("PPK v%d %s: private key does not match public key", version, keyType)
+				}
+			}
+		}
+	}
+}
+
+// The PPK files in testdata/putty were written for the OpenSSH keys next to
+// them by an implementation of the PuTTY file format documentation that is
+// independent of this package. Unencrypted and PPK v2 files are deterministic
+// and must match byte for byte. Encrypted PPK v3 files use a random salt, so
+// the Argon2id fixture is checked with readPuTTYKey instead.
+func TestPrivateKeyPuTTYKnownAnswer(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range []string{"ed25519", "ecdsa", "rsa"} {
+		pemBytes, err := os.ReadFile(filepath.Join("testdata", "putty", keyType+".key"))
+		if err != nil {
+			t.Fatalf("error reading private key: %v", err)
+		}
+
+		for _, fixture := range []struct {
+			name       string
+			version    keygen.PPKVersion
+			passphrase string
+		}{
+			{keyType + "-v2.ppk", keygen.PPKVersion2, ""},
+			{keyType + "-v3.ppk", keygen.PPKVersion3, ""},
+			{keyType + "-v2-encrypted.ppk", keygen.PPKVersion2, "secret"},
+		} {
+			want, err := os.ReadFile(filepath.Join("testdata", "putty", fixture.name))
+			if err != nil {
+				t.Fatalf("error reading fixture: %v", err)
+			}
+
+			key, err := keygen.Parse(pemBytes, nil)
+			if err != nil {
+				t.Fatalf("error parsing private key: %v", err)
+			}
+
+			key.Passphrase = []byte(fixture.passphrase)
+
+			if got := key.PrivateKeyPuTTY(fixture.version); !bytes.Equal(got, want) {
+				t.Errorf("%s: expected\n%s\ngot\n%s", fixture.name, want, got)
+			}
+		}
+	}
+
+	pemBytes, err := os.ReadFile(filepath.Join("testdata", "putty", "ed25519.key"))
+	if err != nil {
+		t.Fatalf("error reading private key: %v", err)
+	}
+
+	ppk, err := os.ReadFile(filepath.Join("testdata", "putty", "ed25519-v3-encrypted.ppk"))
+	if err != nil {
+		t.Fatalf("error reading fixture: %v", err)
+	}
+
+	key, err := keygen.Parse(pemBytes, nil)
+	if err != nil {
+		t.Fatalf("error parsing private key: %v", err)
+	}
+
+	pub, err := readPuTTYKey(t, ppk, []byte("secret"))
+	if err != nil {
+		t.Fatalf("error reading fixture: %v", err)
+	}
+
+	if ssh.FingerprintSHA256(pub) != key.SHA256() {
+		t.Error("ed25519-v3-encrypted.ppk: private key does not match public key")
+	}
+}
+
+// readPuTTYKey verifies the MAC of a PPK file, decrypts it and returns the
+// public key derived from its private part.
+//
+//nolint:funlen,cyclop,ireturn
+func readPuTTYKey(t *testing.T, ppk, passphrase []byte) (ssh.PublicKey, error) {
+	t.Helper()
+
+	fields := map[string]string{}
+	blobs := map[string][]byte{}
+	scanner := bufio.NewScanner(bytes.NewReader(ppk))
+
+	for scanner.Scan() {
+		name, value, _ := strings.Cut(scanner.Text(), ": ")
+		fields[name] = value
+
+		if name == "Public-Lines" || name == "Private-Lines" {
+			lines, _ := strconv.Atoi(value)
+
+			var encoded string
+
+			for range lines {
+				scanner.Scan()
+				encoded += scanner.Text()
+			}
+
+			blob, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				t.Fatalf("invalid %s: %v", name, err)
+			}
+
+			blobs[name] = blob
+		}
+	}
+
+	var (
+		algorithm, version string
+		cipherKey, iv      []byte
+		macKey             []byte
+		mac                func() hash.Hash
+	)
+
+	for name, value := range fields {
+		if v, ok := strings.CutPrefix(name, "PuTTY-User-Key-File-"); ok {
+			version, algorithm = v, value
+		}
+	}
+
+	switch version {
+	case "2":
+		for i := range uint32(2) {
+			h := sha1.New() //nolint:gosec
+			_ = binary.Write(h, binary.BigEndian, i)
+			h.Write(passphrase)
+			cipherKey = h.Sum(cipherKey)
+		}
+
+		sum := sha1.Sum(append([]byte("putty-private-key-file-mac-key"), passphrase...)) //nolint:gosec
+		cipherKey, iv, macKey, mac = cipherKey[:32], make([]byte, 16), sum[:], sha1.New
+	case "3":
+		mac = sha256.New
+
+		if fields["Encryption"] != "none" {
+			salt, _ := hex.DecodeString(fields["Argon2-Salt"])
+			memory, _ := strconv.Atoi(fields["Argon2-Memory"])
+			passes, _ := strconv.Atoi(fields["Argon2-Passes"])
+			threads, _ := strconv.Atoi(fields["Argon2-Parallelism"])
+
+			if fields["Key-Derivation"] != "Argon2id" {
+				t.Fatalf("unexpected key derivation %q", fields["Key-Derivation"])
+			}
+
+			//nolint:gosec
+			keys := argon2.IDKey(passphrase, salt, uint32(passes), uint32(memory), uint8(threads), 80)
+			cipherKey, iv, macKey = keys[:32], keys[32:48], keys[48:]
+		}
+	default:
+		t.Fatalf("unexpected PPK version %q", version)
+	}
+
+	private := blobs["Private-Lines"]
+
+	if fields["Encryption"] == "aes256-cbc" {
+		block, _ := aes.NewCipher(cipherKey)
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(private, private)
+	} else if fields["Encryption"] != "none" {
+		t.Fatalf("unexpected encryption %q", fields["Encryption"])
+	}
+
+	h := hmac.New(mac, macKey)
+	h.Write(ssh.Marshal(struct {
+		Algorithm, Encryption, Comment string
+		Public, Private                []byte
+	}{algorithm, fields["Encryption"], fields["Comment"], blobs["Public-Lines"], private}))
+
+	if hex.EncodeToString(h.Sum(nil)) != fields["Private-MAC"] {
+		t.Fatalf("PPK v%s %s: MAC mismatch", version, algorithm)
+	}
+
+	if fields["Comment"] != "test@example.com" {
+		t.Errorf("PPK v%s %s: unexpected comment %q", version, algorithm, fields["Comment"])
+	}
+
+	pub, err := ssh.ParsePublicKey(blobs["Public-Lines"])
+	if err != nil {
+		t.Fatalf("invalid public key: %v", err)
+	}
+
+	switch algorithm {
+	case ssh.KeyAlgoRSA:
+		var priv struct {
+			D, P, Q, Iqmp *big.Int
+			Rest          []byte `ssh:"rest"`
+		}
+
+		if err = ssh.Unmarshal(private, &priv); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		key := &rsa.PrivateKey{D: priv.D, Primes: []*big.Int{priv.P, priv.Q}}
+		key.N = new(big.Int).Mul(priv.P, priv.Q)
+		key.E = pub.(ssh.CryptoPublicKey).CryptoPublicKey().(*rsa.PublicKey).E //nolint:forcetypeassert
+
+		if err = key.Validate(); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if new(big.Int).Mod(new(big.Int).Mul(priv.Iqmp, priv.Q), priv.P).Cmp(big.NewInt(1)) != 0 {
+			t.Fatalf("invalid iqmp")
+		}
+
+		return ssh.NewPublicKey(&key.PublicKey) //nolint:wrapcheck
+	case ssh.KeyAlgoED25519:
+		var priv struct {
+			Seed []byte
+			Rest []byte `ssh:"rest"`
+		}
+
+		if err = ssh.Unmarshal(private, &priv); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		//nolint:wrapcheck,forcetypeassert
+		return ssh.NewPublicKey(ed25519.NewKeyFromSeed(priv.Seed).Public().(ed25519.PublicKey))
+	default:
+		var priv struct {
+			D    *big.Int
+			Rest []byte `ssh:"rest"`
+		}
+
+		if err = ssh.Unmarshal(private, &priv); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		//nolint:forcetypeassert
+		curve := pub.(ssh.CryptoPublicKey).CryptoPublicKey().(*ecdsa.PublicKey).Curve
+
+		key, err := ecdsa.ParseRawPrivateKey(curve, priv.D.FillBytes(make([]byte, (curve.Params().BitSize+7)/8)))
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return ssh.NewPublicKey(&key.PublicKey) //nolint:wrapcheck
+	}
+}
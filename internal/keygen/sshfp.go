@@ -0,0 +1,72 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// SSHFP algorithm numbers of RFC 4255, RFC 6594 and RFC 7479.
+const (
+	SSHFPAlgorithmRSA     uint8 = 1
+	SSHFPAlgorithmECDSA   uint8 = 3
+	SSHFPAlgorithmED25519 uint8 = 4
+)
+
+// SSHFP fingerprint types of RFC 4255 and RFC 6594.
+const (
+	SSHFPTypeSHA1   uint8 = 1
+	SSHFPTypeSHA256 uint8 = 2
+)
+
+// ErrSSHFPUnsupported indicates a key without SSHFP algorithm number, e.g. a
+// FIDO security key.
+var ErrSSHFPUnsupported = errors.New("no SSHFP algorithm for key")
+
+// SSHFPRecord holds the data of an SSHFP DNS record.
+type SSHFPRecord struct {
+	Algorithm       uint8
+	FingerprintType uint8
+	// Fingerprint is the hex encoded hash of the public key
+	Fingerprint string
+}
+
+// String returns the record data in zone file format, e.g. "4 2 0123...".
+func (r SSHFPRecord) String() string {
+	return fmt.Sprintf("%d %d %s", r.Algorithm, r.FingerprintType, r.Fingerprint)
+}
+
+// SSHFP returns the SSHFP records of the key with SHA-1 and SHA-256
+// fingerprints, like ssh-keygen -r.
+func (p *PublicKey) SSHFP() ([]SSHFPRecord, error) {
+	alg, ok := LookupKeyAlgorithm(p.Key.Type())
+	if !ok || alg.SSHFP == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSSHFPUnsupported, p.Key.Type())
+	}
+
+	blob := p.Key.Marshal()
+	sha1Sum := sha1.Sum(blob) //nolint:gosec
+	sha256Sum := sha256.Sum256(blob)
+
+	return []SSHFPRecord{
+		{Algorithm: alg.SSHFP, FingerprintType: SSHFPTypeSHA1, Fingerprint: hex.EncodeToString(sha1Sum[:])},
+		{Algorithm: alg.SSHFP, FingerprintType: SSHFPTypeSHA256, Fingerprint: hex.EncodeToString(sha256Sum[:])},
+	}, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+func TestPublicKeySSHFP(t *testing.T) {
+	t.Parallel()
+
+	// Expected records as printed by ssh-keygen -r.
+	for publicKey, expected := range map[string][]string{
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIgN1k3iMiRGj0FJdwxZD457aoB02bpl3nBGsMD9N2fQ host": {
+			"4 1 cbcea0252e78db13dcdb077f20571ed5c5715d4d",
+			"4 2 ee6fd3b1c5b34132960859d3b2445dc0c8fff85ecaa31d4ef58fd90831815a95",
+		},
+		"ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBN8+TyawU/hLlsKM5Qr593K7fk5BwbkMfSnpBt" +
+			"RLqMwmlm6ms+4Enc/2ILpzjHUS9bQ2CAaSctgfMpWkZVKUqsk= host": {
+			"3 1 6575631890851ff5f665a2580c01841d1895d659",
+			"3 2 b29c1945fc97994ba121779ef9288eb243bca7c199660923cc6f27db5944b075",
+		},
+	} {
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+		if err != nil {
+			t.Fatalf("error parsing public key: %v", err)
+		}
+
+		records, err := pubKey.SSHFP()
+		if err != nil {
+			t.Fatalf("error creating SSHFP records: %v", err)
+		}
+
+		if len(records) != len(expected) {
+			t.Fatalf("expected %d records, got %v", len(expected), records)
+		}
+
+		for i, record := range records {
+			if record.String() != expected[i] {
+				t.Errorf("expected record %q, got %q", expected[i], record)
+			}
+		}
+	}
+}
+
+func TestPublicKeySSHFPKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range keygen.KeyTypes() {
+		sshkey, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keyType, Bits: 1024})
+		if err != nil {
+			t.Fatalf("error creating %s key pair: %v", keyType, err)
+		}
+
+		pubKey, _ := keygen.ParseAuthorizedKey(sshkey.PublicKey())
+		if _, err = pubKey.SSHFP(); err != nil {
+			t.Errorf("%s: %v", keyType, err)
+		}
+	}
+
+	pubKey, _ := keygen.ParseAuthorizedKey([]byte(testSKEd25519PublicKey))
+	if _, err := pubKey.SSHFP(); !errors.Is(err, keygen.ErrSSHFPUnsupported) {
+		t.Errorf("expected ErrSSHFPUnsupported for security keys, got %v", err)
+	}
+}
@@ -0,0 +1,457 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Hash algorithms of SSH signatures.
+const (
+	SSHSigHashSHA256 = "sha256"
+	SSHSigHashSHA512 = "sha512"
+)
+
+const (
+	sshsigMagic     = "SSHSIG"
+	sshsigVersion   = 1
+	sshsigPEMType   = "SSH SIGNATURE"
+	sshsigLineWidth = 70
+)
+
+var (
+	// ErrSignatureNamespace indicates a missing signature namespace.
+	ErrSignatureNamespace = errors.New("signature namespace must not be empty")
+	// ErrSignatureFormat indicates a malformed SSH signature.
+	ErrSignatureFormat = errors.New("invalid SSH signature")
+	// ErrSignatureMismatch indicates a signature that does not match the
+	// namespace or message.
+	ErrSignatureMismatch = errors.New("signature does not match")
+	// ErrAllowedSigners indicates a malformed allowed_signers file.
+	ErrAllowedSigners = errors.New("invalid allowed_signers")
+)
+
+// sshsigBlob is the SSHSIG signature blob following the magic preamble.
+type sshsigBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshsigSignedData is the data signed by the key following the magic
+// preamble.
+type sshsigSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// Signature holds an SSH signature as created by ssh-keygen -Y sign.
+type Signature struct {
+	PublicKey     ssh.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     *ssh.Signature
+}
+
+// Sign signs the message within the namespace like ssh-keygen -Y sign, using
+// SHA-512 and rsa-sha2-512 for RSA keys. The signature is deterministic, i.e.
+// signing the same message twice returns the same signature.
+func (s *SSHKeyPair) Sign(namespace string, message []byte) (*Signature, error) {
+	if namespace == "" {
+		return nil, ErrSignatureNamespace
+	}
+
+	key := s.PrivateKey()
+	if key == nil {
+		return nil, ErrMissingSSHKeys
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	algorithmSigner, ok := signer.(ssh.AlgorithmSigner)
+	if !ok {
+		return nil, UnsupportedKeyTypeError{Type: signer.PublicKey().Type()}
+	}
+
+	algorithm := ""
+	if signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		algorithm = ssh.KeyAlgoRSASHA512
+	}
+
+	// no randomness makes ECDSA signatures deterministic (RFC 6979), all
+	// other algorithms are deterministic anyway.
+	sig, err := algorithmSigner.SignWithAlgorithm(nil, sshsigData(namespace, SSHSigHashSHA512, message), algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	return &Signature{
+		PublicKey:     signer.PublicKey(),
+		Namespace:     namespace,
+		HashAlgorithm: SSHSigHashSHA512,
+		Signature:     sig,
+	}, nil
+}
+
+// Marshal returns the signature in the armored format of ssh-keygen.
+func (sig *Signature) Marshal() []byte {
+	blob := append([]byte(sshsigMagic), ssh.Marshal(sshsigBlob{
+		Version:       sshsigVersion,
+		PublicKey:     sig.PublicKey.Marshal(),
+		Namespace:     sig.Namespace,
+		HashAlgorithm: sig.HashAlgorithm,
+		Signature:     ssh.Marshal(sig.Signature),
+	})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var out bytes.Buffer
+
+	out.WriteString("-----BEGIN " + sshsigPEMType + "-----\n")
+
+	for len(encoded) > sshsigLineWidth {
+		out.WriteString(encoded[:sshsigLineWidth] + "\n")
+		encoded = encoded[sshsigLineWidth:]
+	}
+
+	out.WriteString(encoded + "\n")
+	out.WriteString("-----END " + sshsigPEMType + "-----\n")
+
+	return out.Bytes()
+}
+
+// ParseSignature parses an armored SSH signature.
+func ParseSignature(in []byte) (*Signature, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(in))
+	if block == nil || block.Type != sshsigPEMType {
+		return nil, fmt.Errorf("%w: missing %s armor", ErrSignatureFormat, sshsigPEMType)
+	}
+
+	data, found := bytes.CutPrefix(block.Bytes, []byte(sshsigMagic))
+	if !found {
+		return nil, fmt.Errorf("%w: missing %s preamble", ErrSignatureFormat, sshsigMagic)
+	}
+
+	var blob sshsigBlob
+	if err := ssh.Unmarshal(data, &blob); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureFormat, err)
+	}
+
+	if blob.Version != sshsigVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrSignatureFormat, blob.Version)
+	}
+
+	pubKey, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureFormat, err)
+	}
+
+	sig := new(ssh.Signature)
+	if err = ssh.Unmarshal(blob.Signature, sig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureFormat, err)
+	}
+
+	return &Signature{
+		PublicKey:     pubKey,
+		Namespace:     blob.Namespace,
+		HashAlgorithm: blob.HashAlgorithm,
+		Signature:     sig,
+	}, nil
+}
+
+// Verify checks that the signature was made for the message within the
+// namespace. It does not check whether the signing key is trusted.
+func (sig *Signature) Verify(namespace string, message []byte) error {
+	if sig.Namespace != namespace {
+		return fmt.Errorf("%w: namespace %q instead of %q", ErrSignatureMismatch, sig.Namespace, namespace)
+	}
+
+	if sig.HashAlgorithm != SSHSigHashSHA256 && sig.HashAlgorithm != SSHSigHashSHA512 {
+		return fmt.Errorf("%w: unsupported hash algorithm %q", ErrSignatureFormat, sig.HashAlgorithm)
+	}
+
+	// like OpenSSH, reject RSA signatures using SHA-1.
+	if sig.Signature.Format == ssh.KeyAlgoRSA {
+		return fmt.Errorf("%w: unsupported signature algorithm %q", ErrSignatureFormat, sig.Signature.Format)
+	}
+
+	if err := sig.PublicKey.Verify(sshsigData(namespace, sig.HashAlgorithm, message), sig.Signature); err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+
+	return nil
+}
+
+// sshsigData returns the data signed for a message.
+func sshsigData(namespace, hashAlgorithm string, message []byte) []byte {
+	var hasher hash.Hash
+
+	if hashAlgorithm == SSHSigHashSHA256 {
+		hasher = sha256.New()
+	} else {
+		hasher = sha512.New()
+	}
+
+	hasher.Write(message)
+
+	return append([]byte(sshsigMagic), ssh.Marshal(sshsigSignedData{
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          hasher.Sum(nil),
+	})...)
+}
+
+// AllowedSigner is an entry of an allowed_signers file as used by
+// ssh-keygen -Y verify.
+type AllowedSigner struct {
+	// Principals are patterns like *@example.com matching signer identities
+	Principals []string
+	// Namespaces are patterns the signature namespace has to match; empty
+	// means any namespace
+	Namespaces []string
+	// ValidAfter and ValidBefore restrict the validity of the key, the zero
+	// time means no restriction
+	ValidAfter  time.Time
+	ValidBefore time.Time
+	Key         ssh.PublicKey
+}
+
+// ParseAllowedSigners parses an allowed_signers file. Empty lines and
+// comments starting with # are skipped. Certificate authorities are not
+// supported, since signatures made with certificates are not.
+func ParseAllowedSigners(in []byte) ([]AllowedSigner, error) {
+	var signers []AllowedSigner
+
+	for i, line := range strings.Split(string(in), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		signer, err := parseAllowedSigner(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+func parseAllowedSigner(line string) (AllowedSigner, error) {
+	var signer AllowedSigner
+
+	principals, rest, err := cutAllowedSignerPrincipals(line)
+	if err != nil {
+		return signer, err
+	}
+
+	key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(rest))
+	if err != nil {
+		return signer, fmt.Errorf("%w: failed to parse public key: %w", ErrAllowedSigners, err)
+	}
+
+	signer.Principals = strings.Split(principals, ",")
+	signer.Key = key
+
+	for _, option := range options {
+		name, value, _ := strings.Cut(option, "=")
+		value = strings.ReplaceAll(strings.Trim(value, `"`), `\"`, `"`)
+
+		switch strings.ToLower(name) {
+		case "cert-authority":
+			return signer, fmt.Errorf("%w: cert-authority is not supported", ErrAllowedSigners)
+		case "namespaces":
+			signer.Namespaces = strings.Split(value, ",")
+		case "valid-after":
+			signer.ValidAfter, err = parseAllowedSignerTime(value)
+		case "valid-before":
+			signer.ValidBefore, err = parseAllowedSignerTime(value)
+		default:
+			return signer, fmt.Errorf("%w: unsupported option %q", ErrAllowedSigners, option)
+		}
+
+		if err != nil {
+			return signer, err
+		}
+	}
+
+	return signer, nil
+}
+
+// parseAllowedSignerTime parses a time in the format of ssh-keygen,
+// YYYYMMDD[HHMM[SS]], in UTC with a trailing Z or UTC and local time
+// otherwise.
+func parseAllowedSignerTime(value string) (time.Time, error) {
+	trimmed, location := value, time.Local
+
+	for _, suffix := range []string{"Z", "UTC"} {
+		if len(value) > len(suffix) && strings.EqualFold(value[len(value)-len(suffix):], suffix) {
+			trimmed, location = value[:len(value)-len(suffix)], time.UTC
+		}
+	}
+
+	layout, ok := map[int]string{
+		len("20060102"):       "20060102",
+		len("200601021504"):   "200601021504",
+		len("20060102150405"): "20060102150405",
+	}[len(trimmed)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: invalid time %q", ErrAllowedSigners, value)
+	}
+
+	t, err := time.ParseInLocation(layout, trimmed, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid time %q: %w", ErrAllowedSigners, value, err)
+	}
+
+	return t, nil
+}
+
+// cutAllowedSignerPrincipals splits the optionally quoted principals off an
+// allowed_signers line.
+func cutAllowedSignerPrincipals(line string) (string, string, error) {
+	if rest, found := strings.CutPrefix(line, `"`); found {
+		principals, rest, found := strings.Cut(rest, `"`)
+		if !found {
+			return "", "", fmt.Errorf("%w: unterminated quoted principals", ErrAllowedSigners)
+		}
+
+		return principals, strings.TrimSpace(rest), nil
+	}
+
+	principals, rest, found := strings.Cut(line, " ")
+	if !found {
+		return "", "", fmt.Errorf("%w: missing public key", ErrAllowedSigners)
+	}
+
+	return principals, strings.TrimSpace(rest), nil
+}
+
+// Allows reports whether the signer may sign as principal within namespace
+// using key at the given time.
+func (a *AllowedSigner) Allows(principal, namespace string, key ssh.PublicKey, at time.Time) bool {
+	if !bytes.Equal(a.Key.Marshal(), key.Marshal()) {
+		return false
+	}
+
+	if !a.ValidAfter.IsZero() && at.Before(a.ValidAfter) || !a.ValidBefore.IsZero() && at.After(a.ValidBefore) {
+		return false
+	}
+
+	if len(a.Namespaces) > 0 && !matchPatternList(namespace, a.Namespaces) {
+		return false
+	}
+
+	return matchPatternList(principal, a.Principals)
+}
+
+// VerifyAllowedSigners verifies the signature like ssh-keygen -Y verify,
+// checking that the signing key is allowed to sign as principal within the
+// namespace at the given time.
+func (sig *Signature) VerifyAllowedSigners(
+	signers []AllowedSigner,
+	principal string,
+	namespace string,
+	message []byte,
+	at time.Time,
+) error {
+	if err := sig.Verify(namespace, message); err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(signers, func(signer AllowedSigner) bool {
+		return signer.Allows(principal, namespace, sig.PublicKey, at)
+	}) {
+		return fmt.Errorf("%w: %s is not allowed to sign as %q at %s", ErrSignatureMismatch,
+			ssh.FingerprintSHA256(sig.PublicKey), principal, at.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// matchPatternList matches value against OpenSSH patterns, where negated
+// patterns starting with ! take precedence.
+func matchPatternList(value string, patterns []string) bool {
+	matched := false
+
+	for _, pattern := range patterns {
+		if negated, found := strings.CutPrefix(pattern, "!"); found {
+			if matchPattern(value, negated) {
+				return false
+			}
+
+			continue
+		}
+
+		if matchPattern(value, pattern) {
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// matchPattern matches value against a pattern with the wildcards * and ?.
+func matchPattern(value, pattern string) bool {
+	for pattern != "" {
+		switch pattern[0] {
+		case '*':
+			pattern = pattern[1:]
+			for i := len(value); i >= 0; i-- {
+				if matchPattern(value[i:], pattern) {
+					return true
+				}
+			}
+
+			return false
+		case '?':
+			if value == "" {
+				return false
+			}
+		default:
+			if value == "" || value[0] != pattern[0] {
+				return false
+			}
+		}
+
+		value = value[1:]
+		pattern = pattern[1:]
+	}
+
+	return value == ""
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package keygen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Signature of "hello world\n" in the namespace file as created by
+// ssh-keygen -Y sign.
+const (
+	sshsigPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPtzYsiU9ok+kqKSKeTxblsE436a0FYbq3byigdnRtH8 test"
+	sshsigSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAg+3NiyJT2iT6SopIp5PFuWwTjfp
+rQVhurdvKKB2dG0fwAAAAEZmlsZQAAAAAAAAAGc2hhNTEyAAAAUwAAAAtzc2gtZWQyNTUx
+OQAAAECRFPBo3+ikT2PdxVtbq1nsQVOm0dQcUL3GXzr+0L4gpPiIsb6sHjzmNkvPVCHi/f
++lXVbxR3r0VvY/5oK6Z2YM
+-----END SSH SIGNATURE-----
+`
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("hello world\n")
+
+	for _, conf := range []keygen.SSHKeyPairConfig{
+		{Type: keygen.RSA, Bits: 2048},
+		{Type: keygen.ED25519},
+		{Type: keygen.ECDSA, Curve: keygen.P256},
+		{Type: keygen.ECDSA, Curve: keygen.P521},
+	} {
+		key, err := keygen.New(&conf)
+		if err != nil {
+			t.Fatalf("%s: error generating SSH key pair: %v", conf.Type, err)
+		}
+
+		sig, err := key.Sign("file", message)
+		if err != nil {
+			t.Fatalf("%s: error signing message: %v", conf.Type, err)
+		}
+
+		other, err := key.Sign("file", message)
+		if err != nil {
+			t.Fatalf("%s: error signing message: %v", conf.Type, err)
+		}
+
+		if !bytes.Equal(sig.Marshal(), other.Marshal()) {
+			t.Errorf("%s: expected deterministic signatures", conf.Type)
+		}
+
+		parsed, err := keygen.ParseSignature(sig.Marshal())
+		if err != nil {
+			t.Fatalf("%s: error parsing signature: %v", conf.Type, err)
+		}
+
+		if err = parsed.Verify("file", message); err != nil {
+			t.Errorf("%s: error verifying signature: %v", conf.Type, err)
+		}
+
+		if err = parsed.Verify("git", message); !errors.Is(err, keygen.ErrSignatureMismatch) {
+			t.Errorf("%s: expected ErrSignatureMismatch for wrong namespace, got %v", conf.Type, err)
+		}
+
+		if err = parsed.Verify("file", []byte("hello mars\n")); !errors.Is(err, keygen.ErrSignatureMismatch) {
+			t.Errorf("%s: expected ErrSignatureMismatch for wrong message, got %v", conf.Type, err)
+		}
+	}
+}
+
+func TestSignEmptyNamespace(t *testing.T) {
+	t.Parallel()
+
+	key, err := keygen.New(&keygen.SSHKeyPairConfig{Type: keygen.ED25519})
+	if err != nil {
+		t.Fatalf("error generating SSH key pair: %v", err)
+	}
+
+	if _, err = key.Sign("", []byte("message")); !errors.Is(err, keygen.ErrSignatureNamespace) {
+		t.Errorf("expected ErrSignatureNamespace, got %v", err)
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	t.Parallel()
+
+	sig, err := keygen.ParseSignature([]byte(sshsigSignature))
+	if err != nil {
+		t.Fatalf("error parsing signature: %v", err)
+	}
+
+	if sig.Namespace != "file" || sig.HashAlgorithm != keygen.SSHSigHashSHA512 {
+		t.Errorf("expected namespace file and hash sha512, got %s and %s", sig.Namespace, sig.HashAlgorithm)
+	}
+
+	if err = sig.Verify("file", []byte("hello world\n")); err != nil {
+		t.Errorf("error verifying signature: %v", err)
+	}
+
+	if string(sig.Marshal()) != sshsigSignature {
+		t.Errorf("expected marshaled signature\n%s\ngot\n%s", sshsigSignature, sig.Marshal())
+	}
+
+	for _, in := range []string{
+		"",
+		"-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+		"-----BEGIN PUBLIC KEY-----\nU1NIU0lH\n-----END PUBLIC KEY-----\n",
+	} {
+		if _, err = keygen.ParseSignature([]byte(in)); !errors.Is(err, keygen.ErrSignatureFormat) {
+			t.Errorf("%q: expected ErrSignatureFormat, got %v", in, err)
+		}
+	}
+}
+
+func TestVerifyAllowedSigners(t *testing.T) {
+	t.Parallel()
+
+	signers, err := keygen.ParseAllowedSigners([]byte(`# allowed signers
+*@example.com,!bot@example.com namespaces="file,git" ` + sshsigPublicKey + `
+
+"ops@example.com" valid-after="20250101" ` + sshsigPublicKey + `
+`))
+	if err != nil {
+		t.Fatalf("error parsing allowed signers: %v", err)
+	}
+
+	if len(signers) != 2 || !signers[1].ValidAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)) ||
+		len(signers[0].Namespaces) != 2 {
+		t.Fatalf("unexpected allowed signers %+v", signers)
+	}
+
+	sig, err := keygen.ParseSignature([]byte(sshsigSignature))
+	if err != nil {
+		t.Fatalf("error parsing signature: %v", err)
+	}
+
+	message := []byte("hello world\n")
+	now := time.Now()
+
+	for _, principal := range []string{"test@example.com", "ops@example.com"} {
+		if err = sig.VerifyAllowedSigners(signers, principal, "file", message, now); err != nil {
+			t.Errorf("%s: error verifying signature: %v", principal, err)
+		}
+	}
+
+	for _, principal := range []string{"bot@example.com", "test@example.org"} {
+		err = sig.VerifyAllowedSigners(signers, principal, "file", message, now)
+		if !errors.Is(err, keygen.ErrSignatureMismatch) {
+			t.Errorf("%s: expected ErrSignatureMismatch, got %v", principal, err)
+		}
+	}
+}
+
+func TestVerifyAllowedSignersValidity(t *testing.T) {
+	t.Parallel()
+
+	signers, err := keygen.ParseAllowedSigners([]byte(
+		`test@example.com valid-after="20250101",valid-before="202507011200Z" ` + sshsigPublicKey,
+	))
+	if err != nil {
+		t.Fatalf("error parsing allowed signers: %v", err)
+	}
+
+	if !signers[0].ValidBefore.Equal(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected valid-before %s", signers[0].ValidBefore)
+	}
+
+	sig, err := keygen.ParseSignature([]byte(sshsigSignature))
+	if err != nil {
+		t.Fatalf("error parsing signature: %v", err)
+	}
+
+	message := []byte("hello world\n")
+
+	for at, valid := range map[time.Time]bool{
+		time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC): false, // not yet valid
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC):   true,
+		time.Date(2025, 7, 1, 12, 0, 1, 0, time.UTC):  false, // expired
+	} {
+		err = sig.VerifyAllowedSigners(signers, "test@example.com", "file", message, at)
+
+		switch {
+		case valid && err != nil:
+			t.Errorf("%s: error verifying signature: %v", at, err)
+		case !valid && !errors.Is(err, keygen.ErrSignatureMismatch):
+			t.Errorf("%s: expected ErrSignatureMismatch, got %v", at, err)
+		}
+	}
+}
+
+func TestParseAllowedSignersInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{
+		"test@example.com",
+		`"test@example.com ` + sshsigPublicKey,
+		"test@example.com ssh-ed25519 invalid",
+		"test@example.com no-touch-required " + sshsigPublicKey,
+		"*@example.com cert-authority " + sshsigPublicKey,
+		`test@example.com valid-before="2025-01-01" ` + sshsigPublicKey,
+		`test@example.com valid-after="20251301" ` + sshsigPublicKey,
+	} {
+		if _, err := keygen.ParseAllowedSigners([]byte(in)); !errors.Is(err, keygen.ErrAllowedSigners) {
+			t.Errorf("%q: expected ErrAllowedSigners, got %v", in, err)
+		}
+	}
+}
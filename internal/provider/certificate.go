@@ -0,0 +1,88 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+// signCertificate signs a certificate with the given certificate authority
+// private key. Errors are added to diags and result in a nil certificate.
+func signCertificate(
+	caPrivateKey types.String,
+	passphrase types.String,
+	conf *keygen.CertificateConfig,
+	diags *diag.Diagnostics,
+) *keygen.Certificate {
+	ca, err := keygen.Parse([]byte(caPrivateKey.ValueString()), []byte(passphrase.ValueString()))
+	if err != nil {
+		diags.AddError("Unable to read certificate authority private key", err.Error())
+
+		return nil
+	}
+
+	cert, err := ca.SignCertificate(conf)
+	if err != nil {
+		diags.AddError("Certificate signing failed", err.Error())
+
+		return nil
+	}
+
+	return cert
+}
+
+// caPublicKey returns the public key of the certificate authority that signed
+// the certificate.
+func caPublicKey(cert *keygen.Certificate) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert.Cert.SignatureKey)))
+}
+
+// extensionsMap converts a set of certificate extensions into the map used by
+// ssh.Permissions. Extensions carry no value.
+func extensionsMap(ctx context.Context, set types.Set, diags *diag.Diagnostics) map[string]string {
+	var extensions []string
+
+	diags.Append(set.ElementsAs(ctx, &extensions, false)...)
+
+	result := make(map[string]string, len(extensions))
+	for _, ext := range extensions {
+		result[ext] = ""
+	}
+
+	return result
+}
+
+// parseTimestamp parses an optional RFC 3339 timestamp. A null value results
+// in the zero time.
+func parseTimestamp(value types.String, diags *diag.Diagnostics) time.Time {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}
+	}
+
+	ts, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid RFC 3339 timestamp", err.Error())
+	}
+
+	return ts
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+var _ planmodifier.String = ecdsaCurveDefaultModifier{}
+
+// ecdsaCurveDefault returns a plan modifier that sets the default ECDSA curve
+// when the key type is `ecdsa` and clears the curve for all other key types.
+// The curve of an existing key is kept.
+func ecdsaCurveDefault() planmodifier.String { //nolint:ireturn
+	return ecdsaCurveDefaultModifier{}
+}
+
+type ecdsaCurveDefaultModifier struct{}
+
+func (m ecdsaCurveDefaultModifier) Description(ctx context.Context) string {
+	return strings.ReplaceAll(m.MarkdownDescription(ctx), "`", "")
+}
+
+func (m ecdsaCurveDefaultModifier) MarkdownDescription(_ context.Context) string {
+	var defaults []string
+
+	for _, keyType := range keygen.KeyTypes() {
+		if impl, _ := keygen.LookupKeyType(keyType); impl.DefaultCurve != "" {
+			defaults = append(defaults, fmt.Sprintf("`%s` for `%s` keys", impl.DefaultCurve, keyType))
+		}
+	}
+
+	return "Defaults to the provider's `default_ecdsa_curve`, otherwise to " + strings.Join(defaults, ", ") + "."
+}
+
+func (m ecdsaCurveDefaultModifier) PlanModifyString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	// Keep the curve of existing, e.g. imported, keys.
+	if !req.StateValue.IsNull() {
+		resp.PlanValue = req.StateValue
+
+		return
+	}
+
+	var keyType types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &keyType)...)
+
+	if resp.Diagnostics.HasError() || keyType.IsUnknown() {
+		return
+	}
+
+	impl, _ := keygen.LookupKeyType(keygen.KeyType(keyType.ValueString()))
+	resp.PlanValue = optionalString(string(impl.DefaultCurve))
+}
+
+// legacyECDSACurve is the curve of all ecdsa keys created before the curve was
+// configurable.
+const legacyECDSACurve = keygen.P384
+
+// ecdsaCurveChanged requires replacement when the curve changes. Resources
+// created before the curve was configurable have no curve in state and adopt
+// their actual curve in place.
+func ecdsaCurveChanged(
+	_ context.Context,
+	req planmodifier.StringRequest,
+	resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
+) {
+	resp.RequiresReplace = !req.StateValue.IsNull() || req.PlanValue.ValueString() != string(legacyECDSACurve)
+}
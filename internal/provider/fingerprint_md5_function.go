@@ -0,0 +1,82 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &FingerprintMD5Function{}
+
+func NewFingerprintMD5Function() function.Function { //nolint:ireturn
+	return &FingerprintMD5Function{}
+}
+
+// FingerprintMD5Function defines the function implementation.
+type FingerprintMD5Function struct{}
+
+func (f *FingerprintMD5Function) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "fingerprint_md5"
+}
+
+func (f *FingerprintMD5Function) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary:             "OpenSSH key md5 fingerprint",
+		MarkdownDescription: "Returns the OpenSSH key md5 fingerprint of an OpenSSH public key in `authorized_keys` format",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "public_key",
+				MarkdownDescription: "OpenSSH public key in `authorized_keys` format, optionally prefixed with options",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FingerprintMD5Function) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var publicKey string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &publicKey))
+
+	if resp.Error != nil {
+		return
+	}
+
+	pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse public key: "+err.Error())
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, pubKey.MD5()))
+}
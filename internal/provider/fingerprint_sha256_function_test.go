@@ -0,0 +1,62 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFingerprintSHA256Function(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  public_key = join(" ", [
+    "no-pty ssh-ed25519",
+    "AAAAC3NzaC1lZDI1NTE5AAAAIBEmI0+9DDo4wb2dvm25yqFZDDSbo0ksF2nU8v9VxXKJ",
+    "jane@example.com",
+  ])
+}
+
+output "test" {
+  value = provider::sshkey::fingerprint_sha256(local.public_key)
+}
+`,
+				Check: resource.TestCheckOutput("test", "SHA256:0uP5WZhtByJXxa2VvkedC7c6JfWByT9hWXCXl8pcSSw"),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::sshkey::fingerprint_sha256("ssh-ed25519 invalid")
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable\s+to\s+parse\s+public\s+key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// keyTypeStrings returns the names of the registered key types.
+func keyTypeStrings() []string {
+	return stringsOf(keygen.KeyTypes())
+}
+
+// curveStrings returns the curves of the registered key types.
+func curveStrings() []string {
+	return stringsOf(keygen.Curves())
+}
+
+// keyTypeDescription documents the supported key types.
+func keyTypeDescription() string {
+	return "SSH key type. Supported types are " + markdownEnum(keygen.KeyTypes()) + "."
+}
+
+// keyBitsDescription documents the configurable key sizes per key type.
+func keyBitsDescription() string {
+	var parts []string
+
+	for _, keyType := range keygen.KeyTypes() {
+		impl, _ := keygen.LookupKeyType(keyType)
+		if len(impl.AllowedBits) == 0 {
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf(
+			"When `type` is `%s`, the size of the generated key, in bits. Supported sizes are %s (default: `%d`).",
+			keyType, markdownEnum(impl.AllowedBits), impl.DefaultBits,
+		))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// keyCurveDescription documents the supported curves per key type.
+func keyCurveDescription() string {
+	var parts []string
+
+	for _, keyType := range keygen.KeyTypes() {
+		impl, _ := keygen.LookupKeyType(keyType)
+		if len(impl.Curves) == 0 {
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf(
+			"When `type` is `%s`, the NIST curve of the generated key. Supported curves are %s (default: `%s`).",
+			keyType, markdownEnum(impl.Curves), impl.DefaultCurve,
+		))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// validateKeyParameters ensures that bits and curve are supported by the
+// configured key type. Bits are ignored for key types with a fixed size.
+func validateKeyParameters(keyType types.String, bits types.Int64, curve types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if keyType.IsNull() || keyType.IsUnknown() {
+		return diags
+	}
+
+	impl, ok := keygen.LookupKeyType(keygen.KeyType(keyType.ValueString()))
+	if !ok {
+		return diags
+	}
+
+	if !bits.IsNull() && !bits.IsUnknown() && len(impl.AllowedBits) > 0 &&
+		!slices.Contains(impl.AllowedBits, bits.ValueInt64()) {
+		diags.AddAttributeError(
+			path.Root("bits"),
+			"Invalid attribute combination",
+			fmt.Sprintf("bits must be one of %v when type is %q, got %d.",
+				impl.AllowedBits, keyType.ValueString(), bits.ValueInt64()),
+		)
+	}
+
+	if curve.IsNull() || curve.IsUnknown() {
+		return diags
+	}
+
+	if len(impl.Curves) == 0 {
+		diags.AddAttributeError(
+			path.Root("curve"),
+			"Invalid attribute combination",
+			"curve can only be set when type is "+curveKeyTypes()+", got type \""+keyType.ValueString()+"\".",
+		)
+	} else if !slices.Contains(impl.Curves, keygen.Curve(curve.ValueString())) {
+		diags.AddAttributeError(
+			path.Root("curve"),
+			"Invalid attribute combination",
+			fmt.Sprintf("curve must be one of %v when type is %q, got %q.",
+				impl.Curves, keyType.ValueString(), curve.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// curveKeyTypes lists the quoted names of the key types with curves.
+func curveKeyTypes() string {
+	var names []string
+
+	for _, keyType := range keygen.KeyTypes() {
+		if impl, _ := keygen.LookupKeyType(keyType); len(impl.Curves) > 0 {
+			names = append(names, fmt.Sprintf("%q", keyType))
+		}
+	}
+
+	return strings.Join(names, " or ")
+}
+
+// stringsOf converts a list of string based values.
+func stringsOf[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, string(value))
+	}
+
+	return out
+}
+
+// markdownEnum formats values as a list of code spans, e.g. `a`, `b` and `c`.
+func markdownEnum[T any](values []T) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("`%v`", value))
+	}
+
+	if len(quoted) < 2 { //nolint:mnd
+		return strings.Join(quoted, "")
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+var _ validator.String = knownHostValidator{}
+
+// knownHost returns a validator which ensures that a string can be written
+// as host of a known_hosts line.
+func knownHost() validator.String { //nolint:ireturn
+	return knownHostValidator{}
+}
+
+type knownHostValidator struct{}
+
+func (v knownHostValidator) Description(_ context.Context) string {
+	return "value must be a host name, address or pattern without whitespace or commas, " +
+		"and must not start with @, | or #"
+}
+
+func (v knownHostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v knownHostValidator) ValidateString(
+	ctx context.Context,
+	req validator.StringRequest,
+	resp *validator.StringResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := keygen.ValidateKnownHost(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid known_hosts host",
+			v.Description(ctx)+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParsePublicKeyFunction{}
+
+func NewParsePublicKeyFunction() function.Function { //nolint:ireturn
+	return &ParsePublicKeyFunction{}
+}
+
+// ParsePublicKeyFunction defines the function implementation.
+type ParsePublicKeyFunction struct{}
+
+// ParsePublicKeyFunctionModel describes the object returned by the function.
+type ParsePublicKeyFunctionModel struct {
+	Algorithm           types.String `tfsdk:"algorithm"`
+	Type                types.String `tfsdk:"type"`
+	Bits                types.Int64  `tfsdk:"bits"`
+	Curve               types.String `tfsdk:"curve"`
+	SecurityKey         types.Bool   `tfsdk:"security_key"`
+	Comment             types.String `tfsdk:"comment"`
+	Options             []string     `tfsdk:"options"`
+	NormalizedPublicKey types.String `tfsdk:"normalized_public_key"`
+	FingerprintMD5      types.String `tfsdk:"fingerprint_md5"`
+	FingerprintSHA256   types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (f *ParsePublicKeyFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_public_key"
+}
+
+func (f *ParsePublicKeyFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an OpenSSH public key",
+		MarkdownDescription: "Parses an OpenSSH public key in `authorized_keys` format and returns an object with " +
+			"the attributes `algorithm`, `type`, `bits`, `curve`, `security_key`, `comment`, `options`, " +
+			"`normalized_public_key`, `fingerprint_md5` and `fingerprint_sha256`, " +
+			"as known from the `sshkey_public_key` data source",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "public_key",
+				MarkdownDescription: "OpenSSH public key in `authorized_keys` format, optionally prefixed with options",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"algorithm":             types.StringType,
+				"type":                  types.StringType,
+				"bits":                  types.Int64Type,
+				"curve":                 types.StringType,
+				"security_key":          types.BoolType,
+				"comment":               types.StringType,
+				"options":               types.ListType{ElemType: types.StringType},
+				"normalized_public_key": types.StringType,
+				"fingerprint_md5":       types.StringType,
+				"fingerprint_sha256":    types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParsePublicKeyFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var publicKey string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &publicKey))
+
+	if resp.Error != nil {
+		return
+	}
+
+	pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse public key: "+err.Error())
+
+		return
+	}
+
+	options := pubKey.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	result := ParsePublicKeyFunctionModel{
+		Algorithm:           types.StringValue(pubKey.Algorithm()),
+		Type:                optionalString(string(pubKey.Type())),
+		Bits:                types.Int64Value(int64(pubKey.Bits())),
+		Curve:               optionalString(string(pubKey.Curve())),
+		SecurityKey:         types.BoolValue(pubKey.SecurityKey()),
+		Comment:             types.StringValue(pubKey.Comment),
+		Options:             options,
+		NormalizedPublicKey: types.StringValue(string(pubKey.Marshal())),
+		FingerprintMD5:      types.StringValue(pubKey.MD5()),
+		FingerprintSHA256:   types.StringValue(pubKey.SHA256()),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, &result))
+}
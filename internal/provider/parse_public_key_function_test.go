@@ -0,0 +1,90 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParsePublicKeyFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  key = provider::sshkey::parse_public_key(join(" ", [
+    "no-pty,from=\"10.0.0.0/8\" ssh-ed25519",
+    "AAAAC3NzaC1lZDI1NTE5AAAAIBEmI0+9DDo4wb2dvm25yqFZDDSbo0ksF2nU8v9VxXKJ",
+    "jane@example.com",
+  ]))
+}
+
+output "algorithm" {
+  value = local.key.algorithm
+}
+
+output "type" {
+  value = local.key.type
+}
+
+output "bits" {
+  value = local.key.bits
+}
+
+output "curve" {
+  value = local.key.curve == null
+}
+
+output "security_key" {
+  value = local.key.security_key
+}
+
+output "comment" {
+  value = local.key.comment
+}
+
+output "options" {
+  value = join(";", local.key.options)
+}
+
+output "fingerprint_sha256" {
+  value = local.key.fingerprint_sha256
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("algorithm", "ssh-ed25519"),
+					resource.TestCheckOutput("type", "ed25519"),
+					resource.TestCheckOutput("bits", "256"),
+					resource.TestCheckOutput("curve", "true"),
+					resource.TestCheckOutput("security_key", "false"),
+					resource.TestCheckOutput("comment", "jane@example.com"),
+					resource.TestCheckOutput("options", "no-pty;from=\"10.0.0.0/8\""),
+					resource.TestCheckOutput("fingerprint_sha256", "SHA256:0uP5WZhtByJXxa2VvkedC7c6JfWByT9hWXCXl8pcSSw"),
+				),
+			},
+		},
+	})
+}
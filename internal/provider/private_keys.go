@@ -0,0 +1,54 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// SSHKeyPairPrivateKeysModel describes the private key encodings shared by
+// the sshkey_pair resource and ephemeral resource.
+type SSHKeyPairPrivateKeysModel struct {
+	PrivateKeyPEM         types.String `tfsdk:"private_key"`
+	PrivateKeyOpenSSH     types.String `tfsdk:"private_key_openssh"`
+	PrivateKeyPKCS8       types.String `tfsdk:"private_key_pem_pkcs8"`
+	PrivateKeyTraditional types.String `tfsdk:"private_key_pem"`
+	PrivateKeyPuTTY       types.String `tfsdk:"private_key_putty"`
+	PrivateKeyJWK         types.String `tfsdk:"private_key_jwk"`
+	PuTTYVersion          types.Int64  `tfsdk:"putty_version"`
+}
+
+// setPrivateKeys stores the private key in all supported encodings.
+func (m *SSHKeyPairPrivateKeysModel) setPrivateKeys(sshkey *keygen.SSHKeyPair) {
+	m.PrivateKeyPEM = types.StringValue(string(sshkey.PrivateKeyPEM()))
+	m.PrivateKeyOpenSSH = m.PrivateKeyPEM
+	m.PrivateKeyPKCS8 = optionalString(string(sshkey.PrivateKeyPKCS8PEM()))
+	m.PrivateKeyTraditional = optionalString(string(sshkey.PrivateKeyTraditionalPEM()))
+	m.PrivateKeyPuTTY = types.StringValue(string(sshkey.PrivateKeyPuTTY(keygen.PPKVersion(m.PuTTYVersion.ValueInt64()))))
+	m.PrivateKeyJWK = optionalString(string(sshkey.PrivateKeyJWK()))
+}
+
+// setPrivateKeysUnknown marks all encodings as unknown, as they change when the
+// private key is re-encoded.
+func (m *SSHKeyPairPrivateKeysModel) setPrivateKeysUnknown() {
+	m.PrivateKeyPEM = types.StringUnknown()
+	m.PrivateKeyOpenSSH = types.StringUnknown()
+	m.PrivateKeyPKCS8 = types.StringUnknown()
+	m.PrivateKeyTraditional = types.StringUnknown()
+	m.PrivateKeyPuTTY = types.StringUnknown()
+	m.PrivateKeyJWK = types.StringUnknown()
+}
@@ -17,15 +17,33 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"math"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
 )
 
+// errUnexpectedProviderData indicates that a resource was configured with
+// data of another provider.
+var errUnexpectedProviderData = errors.New("unexpected provider data")
+
 // Ensure SSHKeyProvider satisfies various provider interfaces.
-var _ provider.Provider = &SSHKeyProvider{}
+var (
+	_ provider.Provider                       = &SSHKeyProvider{}
+	_ provider.ProviderWithFunctions          = &SSHKeyProvider{}
+	_ provider.ProviderWithEphemeralResources = &SSHKeyProvider{}
+)
 
 // SSHKeyProvider defines the provider implementation.
 type SSHKeyProvider struct {
@@ -36,7 +54,13 @@ type SSHKeyProvider struct {
 }
 
 // SSHKeyProviderModel describes the provider data model.
-type SSHKeyProviderModel struct{}
+type SSHKeyProviderModel struct {
+	DefaultType       types.String `tfsdk:"default_type"`
+	DefaultRSABits    types.Int64  `tfsdk:"default_rsa_bits"`
+	DefaultECDSACurve types.String `tfsdk:"default_ecdsa_curve"`
+	DefaultComment    types.String `tfsdk:"default_comment"`
+	DefaultKDFRounds  types.Int64  `tfsdk:"default_kdf_rounds"`
+}
 
 func (p *SSHKeyProvider) Metadata(
 	_ context.Context,
@@ -47,12 +71,67 @@ func (p *SSHKeyProvider) Metadata(
 	resp.Version = p.Version
 }
 
+//
+//nolint:funlen
 func (p *SSHKeyProvider) Schema(
 	_ context.Context,
 	_ provider.SchemaRequest,
 	resp *provider.SchemaResponse,
 ) {
-	resp.Schema = schema.Schema{}
+	rsa, _ := keygen.LookupKeyType(keygen.RSA)
+	ecdsa, _ := keygen.LookupKeyType(keygen.ECDSA)
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Defaults shared by all key pairs of the provider. " +
+			"Settings of a key pair take precedence, and defaults only apply to new keys.",
+
+		Attributes: map[string]schema.Attribute{
+			"default_type": schema.StringAttribute{
+				Description:         "Key type of key pairs without type",
+				MarkdownDescription: "Key type of key pairs without `type`. Supported types are " + markdownEnum(keygen.KeyTypes()) + ".",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(keyTypeStrings()...),
+				},
+			},
+			"default_rsa_bits": schema.Int64Attribute{
+				Description: "Size of rsa keys without bits",
+				MarkdownDescription: "Size of `rsa` keys without `bits`. Supported sizes are " +
+					markdownEnum(rsa.AllowedBits) + ".",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(rsa.AllowedBits...),
+				},
+			},
+			"default_ecdsa_curve": schema.StringAttribute{
+				Description: "Curve of ecdsa keys without curve",
+				MarkdownDescription: "NIST curve of `ecdsa` keys without `curve`. Supported curves are " +
+					markdownEnum(ecdsa.Curves) + ".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(stringsOf(ecdsa.Curves)...),
+				},
+			},
+			"default_comment": schema.StringAttribute{
+				Description: "Comment template of key pairs without comment",
+				MarkdownDescription: "Comment of key pairs without `comment`, as Go template " +
+					"(default: `user@hostname`). Available variables are `{{.User}}`, `{{.Hostname}}`, `{{.Type}}`, " +
+					"`{{.Bits}}`, `{{.Curve}}`, `{{.Fingerprint}}`, `{{.Date}}` and the `keepers` of the key pair, " +
+					"e.g. `{{.Keepers.name}}`. Values known to Terraform, like the workspace, can be interpolated " +
+					"directly, e.g. `\"${terraform.workspace}-{{.Type}}\"`.",
+				Optional: true,
+			},
+			"default_kdf_rounds": schema.Int64Attribute{
+				Description: "bcrypt_pbkdf rounds of encrypted OpenSSH private keys",
+				MarkdownDescription: "Number of `bcrypt_pbkdf` rounds used to encrypt OpenSSH private keys " +
+					"with a passphrase, like `ssh-keygen -a` (default: `16`).",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, math.MaxUint32),
+				},
+			},
+		},
+	}
 }
 
 func (p *SSHKeyProvider) Configure(
@@ -67,16 +146,64 @@ func (p *SSHKeyProvider) Configure(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	providerData := &SSHKeyProviderData{
+		DefaultType:       keygen.KeyType(data.DefaultType.ValueString()),
+		DefaultRSABits:    uint16(data.DefaultRSABits.ValueInt64()), //nolint:gosec
+		DefaultECDSACurve: keygen.Curve(data.DefaultECDSACurve.ValueString()),
+		DefaultKDFRounds:  int(data.DefaultKDFRounds.ValueInt64()),
+	}
+
+	if !data.DefaultComment.IsNull() {
+		tmpl, err := parseCommentTemplate(data.DefaultComment.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_comment"), "Invalid comment template", err.Error())
+
+			return
+		}
+
+		providerData.DefaultComment = tmpl
+	}
+
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 func (p *SSHKeyProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSSHKeyPairResource,
+		NewSSHKeyUserCertificateResource,
+		NewSSHKeyHostCertificateResource,
+		NewSSHKeyKRLResource,
+	}
+}
+
+func (p *SSHKeyProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSSHKeyPairEphemeralResource,
 	}
 }
 
 func (p *SSHKeyProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewSSHKeyPublicKeyDataSource,
+		NewSSHKeyPrivateKeyDataSource,
+		NewSSHKeyJWKSDataSource,
+		NewSSHKeyKnownHostsDataSource,
+		NewSSHKeyAuthorizedKeysDataSource,
+		NewSSHKeySSHFPDataSource,
+	}
+}
+
+func (p *SSHKeyProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFingerprintSHA256Function,
+		NewFingerprintMD5Function,
+		NewPublicKeyFromPrivateFunction,
+		NewParsePublicKeyFunction,
+		NewSignFunction,
+		NewVerifySignatureFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,142 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// SSHKeyProviderData holds the provider configuration shared with resources.
+type SSHKeyProviderData struct {
+	// DefaultType is used when a key pair does not configure its type
+	DefaultType keygen.KeyType
+	// DefaultRSABits is used for rsa keys without configured size
+	DefaultRSABits uint16
+	// DefaultECDSACurve is used for ecdsa keys without configured curve
+	DefaultECDSACurve keygen.Curve
+	// DefaultComment renders the comment of keys without configured comment
+	DefaultComment *template.Template
+	// DefaultKDFRounds are the bcrypt_pbkdf rounds of encrypted OpenSSH keys
+	DefaultKDFRounds int
+}
+
+// commentTemplateData holds the variables available in default_comment.
+type commentTemplateData struct {
+	User        string
+	Hostname    string
+	Type        string
+	Bits        int
+	Curve       string
+	Fingerprint string
+	Date        string
+	Keepers     map[string]string
+}
+
+// parseCommentTemplate parses the default_comment template. Unknown variables
+// are rejected when the comment is rendered.
+func parseCommentTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("default_comment").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse comment template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// applyDefaults completes a key pair configuration with the provider defaults.
+func (d *SSHKeyProviderData) applyDefaults(conf *keygen.SSHKeyPairConfig) {
+	if conf.Type == "" {
+		conf.Type = d.DefaultType
+	}
+
+	if conf.Bits == 0 && conf.Type == keygen.RSA {
+		conf.Bits = d.DefaultRSABits
+	}
+
+	if conf.Curve == "" && conf.Type == keygen.ECDSA {
+		conf.Curve = d.DefaultECDSACurve
+	}
+
+	if conf.KDFRounds == 0 {
+		conf.KDFRounds = d.DefaultKDFRounds
+	}
+}
+
+// defaultCurve returns the curve of new keys of the given type without
+// configured curve.
+func (d *SSHKeyProviderData) defaultCurve(keyType keygen.KeyType) keygen.Curve {
+	if keyType == keygen.ECDSA && d.DefaultECDSACurve != "" {
+		return d.DefaultECDSACurve
+	}
+
+	impl, _ := keygen.LookupKeyType(keyType)
+
+	return impl.DefaultCurve
+}
+
+// comment returns the comment of a new key without configured comment, either
+// rendered from default_comment or user@hostname.
+func (d *SSHKeyProviderData) comment(sshkey *keygen.SSHKeyPair, keepers map[string]string) (string, error) {
+	if d.DefaultComment == nil {
+		return keygen.GetSSHKeyComment(), nil
+	}
+
+	data := commentTemplateData{
+		Type:        string(sshkey.Type),
+		Bits:        int(sshkey.Bits),
+		Curve:       string(sshkey.Curve),
+		Fingerprint: sshkey.SHA256(),
+		Date:        time.Now().UTC().Format(time.DateOnly),
+		Keepers:     keepers,
+	}
+
+	if usr, err := user.Current(); err == nil {
+		data.User = usr.Username
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		data.Hostname = hostname
+	}
+
+	var out bytes.Buffer
+	if err := d.DefaultComment.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render default_comment: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// providerData returns the provider configuration passed to Configure.
+func providerData(data any) (*SSHKeyProviderData, error) {
+	if data == nil {
+		return &SSHKeyProviderData{}, nil
+	}
+
+	providerData, ok := data.(*SSHKeyProviderData)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected *provider.SSHKeyProviderData, got %T", errUnexpectedProviderData, data)
+	}
+
+	return providerData, nil
+}
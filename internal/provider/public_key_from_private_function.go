@@ -0,0 +1,92 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PublicKeyFromPrivateFunction{}
+
+func NewPublicKeyFromPrivateFunction() function.Function { //nolint:ireturn
+	return &PublicKeyFromPrivateFunction{}
+}
+
+// PublicKeyFromPrivateFunction defines the function implementation.
+type PublicKeyFromPrivateFunction struct{}
+
+func (f *PublicKeyFromPrivateFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "public_key_from_private"
+}
+
+func (f *PublicKeyFromPrivateFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "OpenSSH public key of a private key",
+		MarkdownDescription: "Derives the OpenSSH public key in `authorized_keys` format from a private key " +
+			"in OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM format",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "private_key",
+				MarkdownDescription: "Private key in OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM format",
+			},
+			function.StringParameter{
+				Name:                "passphrase",
+				MarkdownDescription: "Passphrase to decrypt the private key; `null` or empty if it is not encrypted",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PublicKeyFromPrivateFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var (
+		privateKey string
+		passphrase types.String
+	)
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &privateKey, &passphrase))
+
+	if resp.Error != nil {
+		return
+	}
+
+	skeys, err := keygen.Parse([]byte(privateKey), []byte(passphrase.ValueString()))
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to parse private key: " + err.Error())
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(skeys.PublicKey())))
+}
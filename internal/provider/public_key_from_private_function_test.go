@@ -0,0 +1,85 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccPublicKeyFromPrivateFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "rsa"
+  bits       = 2048
+  passphrase = "secret"
+}
+
+resource "sshkey_pair" "plain" {
+  type = "ed25519"
+}
+
+output "test" {
+  value = nonsensitive(
+    provider::sshkey::public_key_from_private(sshkey_pair.test.private_key, "secret")
+  ) == sshkey_pair.test.public_key
+}
+
+output "plain" {
+  value = nonsensitive(
+    provider::sshkey::public_key_from_private(sshkey_pair.plain.private_key, null)
+  ) == sshkey_pair.plain.public_key
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "true"),
+					resource.TestCheckOutput("plain", "true"),
+				),
+			},
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "rsa"
+  bits       = 2048
+  passphrase = "secret"
+}
+
+resource "sshkey_pair" "plain" {
+  type = "ed25519"
+}
+
+output "test" {
+  value = nonsensitive(provider::sshkey::public_key_from_private(sshkey_pair.test.private_key, "wrong"))
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable\s+to\s+parse\s+private\s+key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SignFunction{}
+
+func NewSignFunction() function.Function { //nolint:ireturn
+	return &SignFunction{}
+}
+
+// SignFunction defines the function implementation.
+type SignFunction struct{}
+
+func (f *SignFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "sign"
+}
+
+func (f *SignFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Sign a message like ssh-keygen -Y sign",
+		MarkdownDescription: "Signs a message with a private key within a namespace and returns the armored " +
+			"`SSH SIGNATURE` as created by `ssh-keygen -Y sign`, using SHA-512 and `rsa-sha2-512` for RSA keys. " +
+			"Signing is deterministic, so the same message always yields the same signature",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "private_key",
+				MarkdownDescription: "Private key in OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM format",
+			},
+			function.StringParameter{
+				Name:                "passphrase",
+				MarkdownDescription: "Passphrase to decrypt the private key; `null` or empty if it is not encrypted",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "namespace",
+				MarkdownDescription: "Namespace of the signature like `file` or `git`, preventing its use in another context",
+			},
+			function.StringParameter{
+				Name:                "message",
+				MarkdownDescription: "Message to sign",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SignFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var (
+		privateKey string
+		passphrase types.String
+		namespace  string
+		message    string
+	)
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &privateKey, &passphrase, &namespace, &message))
+
+	if resp.Error != nil {
+		return
+	}
+
+	skeys, err := keygen.Parse([]byte(privateKey), []byte(passphrase.ValueString()))
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to parse private key: " + err.Error())
+
+		return
+	}
+
+	sig, err := skeys.Sign(namespace, []byte(message))
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to sign message: " + err.Error())
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(sig.Marshal())))
+}
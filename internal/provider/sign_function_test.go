@@ -0,0 +1,87 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSignFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "ecdsa"
+  passphrase = "secret"
+}
+
+locals {
+  signature = nonsensitive(
+    provider::sshkey::sign(sshkey_pair.test.private_key, "secret", "file", "hello world")
+  )
+  allowed_signers = "test@example.com ${sshkey_pair.test.public_key}"
+}
+
+output "armor" {
+  value = startswith(local.signature, "-----BEGIN SSH SIGNATURE-----\n")
+}
+
+output "deterministic" {
+  value = local.signature == nonsensitive(
+    provider::sshkey::sign(sshkey_pair.test.private_key, "secret", "file", "hello world")
+  )
+}
+
+output "valid" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world", local.signature, null
+  )
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("armor", "true"),
+					resource.TestCheckOutput("deterministic", "true"),
+					resource.TestCheckOutput("valid", "true"),
+				),
+			},
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "ecdsa"
+  passphrase = "secret"
+}
+
+output "test" {
+  value = nonsensitive(provider::sshkey::sign(sshkey_pair.test.private_key, "secret", "", "hello world"))
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable\s+to\s+sign\s+message`),
+			},
+		},
+	})
+}
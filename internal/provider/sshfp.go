@@ -0,0 +1,76 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// sshfpRecordType is the object type of SSHFP records.
+//
+//nolint:gochecknoglobals
+var sshfpRecordType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"algorithm":        types.Int64Type,
+		"fingerprint_type": types.Int64Type,
+		"fingerprint":      types.StringType,
+		"rdata":            types.StringType,
+	},
+}
+
+// sshfpRecordDescriptions documents the attributes of SSHFP records.
+//
+//nolint:gochecknoglobals
+var sshfpRecordDescriptions = map[string]string{
+	"algorithm":        "SSHFP algorithm number, i.e. `1` for `rsa`, `3` for `ecdsa` and `4` for `ed25519` keys",
+	"fingerprint_type": "SSHFP fingerprint type, i.e. `1` for SHA-1 and `2` for SHA-256",
+	"fingerprint":      "Hex encoded fingerprint of the public key",
+	"rdata":            "Record data in zone file format, e.g. `4 2 <fingerprint>`",
+}
+
+// sshfpRecords returns the SSHFP records of a public key as list of objects.
+func sshfpRecords(pubKey *keygen.PublicKey) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	records, err := pubKey.SSHFP()
+	if err != nil {
+		diags.AddError("Unable to create SSHFP records", err.Error())
+
+		return types.ListNull(sshfpRecordType), diags
+	}
+
+	values := make([]attr.Value, 0, len(records))
+
+	for _, record := range records {
+		value, d := types.ObjectValue(sshfpRecordType.AttrTypes, map[string]attr.Value{
+			"algorithm":        types.Int64Value(int64(record.Algorithm)),
+			"fingerprint_type": types.Int64Value(int64(record.FingerprintType)),
+			"fingerprint":      types.StringValue(record.Fingerprint),
+			"rdata":            types.StringValue(record.String()),
+		})
+		diags.Append(d...)
+
+		values = append(values, value)
+	}
+
+	list, d := types.ListValue(sshfpRecordType, values)
+	diags.Append(d...)
+
+	return list, diags
+}
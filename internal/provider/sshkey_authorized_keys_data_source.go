@@ -0,0 +1,244 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeyAuthorizedKeysDataSource{}
+
+func NewSSHKeyAuthorizedKeysDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeyAuthorizedKeysDataSource{}
+}
+
+// SSHKeyAuthorizedKeysDataSource defines the data source implementation.
+type SSHKeyAuthorizedKeysDataSource struct{}
+
+// SSHKeyAuthorizedKeysDataSourceModel describes the data source data model.
+type SSHKeyAuthorizedKeysDataSourceModel struct {
+	ID             types.String                    `tfsdk:"id"`
+	Keys           []SSHKeyAuthorizedKeyEntryModel `tfsdk:"keys"`
+	AuthorizedKeys types.String                    `tfsdk:"authorized_keys"`
+	Fingerprints   []string                        `tfsdk:"fingerprints"`
+}
+
+// SSHKeyAuthorizedKeyEntryModel describes a key entry of the data source.
+type SSHKeyAuthorizedKeyEntryModel struct {
+	PublicKey     string            `tfsdk:"public_key"`
+	Command       types.String      `tfsdk:"command"`
+	From          []string          `tfsdk:"from"`
+	Environment   map[string]string `tfsdk:"environment"`
+	NoPTY         types.Bool        `tfsdk:"no_pty"`
+	Restrict      types.Bool        `tfsdk:"restrict"`
+	ExpiryTime    types.String      `tfsdk:"expiry_time"`
+	Principals    []string          `tfsdk:"principals"`
+	CertAuthority types.Bool        `tfsdk:"cert_authority"`
+}
+
+func (d *SSHKeyAuthorizedKeysDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_authorized_keys"
+}
+
+//
+//nolint:funlen
+func (d *SSHKeyAuthorizedKeysDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders an OpenSSH `authorized_keys` file from public keys and their options",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 checksum of the authorized_keys file",
+			},
+			"keys": schema.ListNestedAttribute{
+				Description: "Public keys and their options",
+				MarkdownDescription: "Public keys and their options, rendered as one line each in the given order. " +
+					"Keys with the same SHA256 fingerprint and options as a previous key are skipped, " +
+					"the same key with different options is an error.",
+				Required: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"public_key": schema.StringAttribute{
+							Description: "OpenSSH public key",
+							MarkdownDescription: "OpenSSH public key in `authorized_keys` format, e.g. " +
+								"`sshkey_pair.example.public_key`. Options preceding the key are kept.",
+							Required: true,
+						},
+						"command": schema.StringAttribute{
+							Description:         "Command forced on login",
+							MarkdownDescription: "Command forced on every login with the key, e.g. `/usr/bin/rrsync -ro /srv`",
+							Optional:            true,
+						},
+						"from": schema.ListAttribute{
+							Description:         "Client addresses the key is accepted from",
+							MarkdownDescription: "Client addresses, CIDR ranges or host name patterns the key is accepted from",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"environment": schema.MapAttribute{
+							Description: "Environment variables set on login",
+							MarkdownDescription: "Environment variables set on login, requires `PermitUserEnvironment` " +
+								"in `sshd_config`",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"no_pty": schema.BoolAttribute{
+							Description:         "Prevent terminal allocation",
+							MarkdownDescription: "Prevent terminal allocation",
+							Optional:            true,
+						},
+						"restrict": schema.BoolAttribute{
+							Description:         "Disable forwarding and terminal allocation",
+							MarkdownDescription: "Disable all port, agent and X11 forwarding as well as terminal allocation",
+							Optional:            true,
+						},
+						"expiry_time": schema.StringAttribute{
+							Description:         "RFC 3339 timestamp after which the key is rejected",
+							MarkdownDescription: "RFC 3339 timestamp after which the key is rejected, written in UTC",
+							Optional:            true,
+							Validators: []validator.String{
+								rfc3339(),
+							},
+						},
+						"principals": schema.ListAttribute{
+							Description: "Certificate principals accepted for the user",
+							MarkdownDescription: "Certificate principals accepted for the user. " +
+								"Only valid together with `cert_authority`.",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"cert_authority": schema.BoolAttribute{
+							Description:         "Trust certificates signed by the key",
+							MarkdownDescription: "Trust user certificates signed by the key instead of the key itself",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"authorized_keys": schema.StringAttribute{
+				Description:         "authorized_keys file",
+				MarkdownDescription: "Content of the `authorized_keys` file",
+				Computed:            true,
+			},
+			"fingerprints": schema.ListAttribute{
+				Description:         "SHA256 fingerprints of the keys",
+				MarkdownDescription: "SHA256 fingerprints of the keys in the file, without duplicates",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeyAuthorizedKeysDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeyAuthorizedKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := make([]*keygen.PublicKey, 0, len(data.Keys))
+
+	for i, entry := range data.Keys {
+		keyPath := path.Root("keys").AtListIndex(i)
+
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(entry.PublicKey))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(keyPath.AtName("public_key"), "Unable to parse public key", err.Error())
+
+			continue
+		}
+
+		options := keygen.AuthorizedKeyOptions{
+			Command:       entry.Command.ValueString(),
+			From:          entry.From,
+			Environment:   entry.Environment,
+			NoPTY:         entry.NoPTY.ValueBool(),
+			Restrict:      entry.Restrict.ValueBool(),
+			ExpiryTime:    parseTimestamp(entry.ExpiryTime, &resp.Diagnostics),
+			Principals:    entry.Principals,
+			CertAuthority: entry.CertAuthority.ValueBool(),
+		}
+
+		list, err := options.List()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(keyPath, "Invalid authorized_keys options", err.Error())
+
+			continue
+		}
+
+		pubKey.Options = append(pubKey.Options, list...)
+		keys = append(keys, pubKey)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authorizedKeys, err := keygen.MarshalAuthorizedKeys(keys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("keys"), "Invalid authorized_keys keys", err.Error())
+
+		return
+	}
+
+	checksum := sha256.Sum256(authorizedKeys)
+
+	data.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	data.AuthorizedKeys = types.StringValue(string(authorizedKeys))
+	data.Fingerprints = []string{}
+
+	for _, key := range keys {
+		if !slices.Contains(data.Fingerprints, key.SHA256()) {
+			data.Fingerprints = append(data.Fingerprints, key.SHA256())
+		}
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
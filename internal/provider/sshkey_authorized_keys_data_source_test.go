@@ -0,0 +1,143 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSSHKeyAuthorizedKeysDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "jane" {
+  type    = "ed25519"
+  comment = "jane@example.com"
+}
+
+resource "sshkey_pair" "ca" {
+  type    = "ed25519"
+  comment = "user-ca"
+}
+
+data "sshkey_authorized_keys" "test" {
+  keys = [
+    {
+      public_key  = sshkey_pair.jane.public_key
+      restrict    = true
+      command     = "echo \"hello\""
+      from        = ["192.0.2.0/24", "*.example.com"]
+      environment = { LANG = "C" }
+      expiry_time = "2030-01-02T03:04:05+01:00"
+    },
+    {
+      public_key     = sshkey_pair.ca.public_key
+      cert_authority = true
+      principals     = ["jane", "ops"]
+    },
+    {
+      public_key = sshkey_pair.jane.public_key
+      no_pty     = true
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.sshkey_authorized_keys.test", "authorized_keys",
+						regexp.MustCompile(`^restrict,command="echo \\"hello\\"",from="192\.0\.2\.0/24,\*\.example\.com",`+
+							`environment="LANG=C",expiry-time="20300102020405Z" ssh-ed25519 \S+ jane@example\.com\n`+
+							`cert-authority,principals="jane,ops" ssh-ed25519 \S+ user-ca\n$`),
+					),
+					resource.TestCheckResourceAttr("data.sshkey_authorized_keys.test", "fingerprints.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_authorized_keys.test", "fingerprints.0",
+						"sshkey_pair.jane", "fingerprint_sha256",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyAuthorizedKeysDataSourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sshkey_authorized_keys" "test" {
+  keys = [
+    {
+      public_key = "ssh-ed25519 invalid"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse public key`),
+			},
+			{
+				Config: `
+resource "sshkey_pair" "jane" {
+  type = "ed25519"
+}
+
+data "sshkey_authorized_keys" "test" {
+  keys = [
+    {
+      public_key = sshkey_pair.jane.public_key
+      principals = ["jane"]
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`principals require cert-authority`),
+			},
+			{
+				Config: `
+resource "sshkey_pair" "jane" {
+  type = "ed25519"
+}
+
+data "sshkey_authorized_keys" "test" {
+  keys = [
+    {
+      public_key = sshkey_pair.jane.public_key
+      restrict   = true
+    },
+    {
+      public_key = sshkey_pair.jane.public_key
+      no_pty     = true
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`duplicate authorized_keys key with different options`),
+			},
+		},
+	})
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &SSHKeyHostCertificateResource{}
+	_ resource.ResourceWithModifyPlan = &SSHKeyHostCertificateResource{}
+)
+
+func NewSSHKeyHostCertificateResource() resource.Resource { //nolint:ireturn
+	return &SSHKeyHostCertificateResource{}
+}
+
+// SSHKeyHostCertificateResource defines the resource implementation.
+type SSHKeyHostCertificateResource struct{}
+
+// SSHKeyHostCertificateResourceModel describes the resource data model.
+type SSHKeyHostCertificateResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	CAPrivateKey           types.String `tfsdk:"ca_private_key"`
+	CAPrivateKeyPassphrase types.String `tfsdk:"ca_private_key_passphrase"`
+	PublicKey              types.String `tfsdk:"public_key"`
+	KeyID                  types.String `tfsdk:"key_id"`
+	Serial                 types.Int64  `tfsdk:"serial"`
+	Principals             types.List   `tfsdk:"principals"`
+	ValidityPeriodHours    types.Int64  `tfsdk:"validity_period_hours"`
+	EarlyRenewalHours      types.Int64  `tfsdk:"early_renewal_hours"`
+	ReadyForRenewal        types.Bool   `tfsdk:"ready_for_renewal"`
+	ValidityStartTime      types.String `tfsdk:"validity_start_time"`
+	ValidityEndTime        types.String `tfsdk:"validity_end_time"`
+	Certificate            types.String `tfsdk:"certificate"`
+	CAPublicKey            types.String `tfsdk:"ca_public_key"`
+}
+
+func (r *SSHKeyHostCertificateResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_host_certificate"
+}
+
+//
+//nolint:funlen
+func (r *SSHKeyHostCertificateResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OpenSSH host certificate signed by a certificate authority key. " +
+			"The certificate is replaced once it enters the renewal window defined by `early_renewal_hours`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 fingerprint of the certificate",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_private_key": schema.StringAttribute{
+				Description:         "Private key of the certificate authority",
+				MarkdownDescription: "Private key of the certificate authority, e.g. `sshkey_pair.ca.private_key`",
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_private_key_passphrase": schema.StringAttribute{
+				Description:         "Passphrase of the certificate authority private key",
+				MarkdownDescription: "Passphrase of the certificate authority private key",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				Description:         "OpenSSH host public key to sign",
+				MarkdownDescription: "OpenSSH host public key to sign, in `authorized_keys` format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				Description:         "Certificate key identifier",
+				MarkdownDescription: "Certificate key identifier",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"serial": schema.Int64Attribute{
+				Description:         "Certificate serial number",
+				MarkdownDescription: "Certificate serial number (default: `0`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.ListAttribute{
+				Description:         "Host names the certificate is valid for",
+				MarkdownDescription: "Host names and addresses the certificate is valid for",
+				ElementType:         types.StringType,
+				Required:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"validity_period_hours": schema.Int64Attribute{
+				Description:         "Number of hours the certificate is valid for",
+				MarkdownDescription: "Number of hours, after initial issuing, that the certificate will remain valid for",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"early_renewal_hours": schema.Int64Attribute{
+				Description: "Number of hours before expiry the certificate is renewed",
+				MarkdownDescription: "The resource will consider the certificate to have expired the given number of hours " +
+					"before its actual expiry time. This can be useful to deploy an updated certificate in advance of " +
+					"the expiration of the current certificate (default: `0`)",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ready_for_renewal": schema.BoolAttribute{
+				Description: "Whether the certificate is within its renewal window",
+				MarkdownDescription: "Is the certificate either expired or ready for an early renewal " +
+					"(i.e. within `early_renewal_hours`)?",
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"validity_start_time": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp the certificate is valid from",
+				MarkdownDescription: "RFC 3339 timestamp the certificate is valid from",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validity_end_time": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp the certificate is valid until",
+				MarkdownDescription: "RFC 3339 timestamp the certificate is valid until",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Description:         "OpenSSH certificate",
+				MarkdownDescription: "OpenSSH certificate, for use in `HostCertificate`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key of the certificate authority",
+				MarkdownDescription: "OpenSSH public key of the certificate authority, for use in `@cert-authority` known_hosts entries",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSHKeyHostCertificateResource) Configure(
+	_ context.Context,
+	_ resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+}
+
+// ModifyPlan marks the certificate for replacement once it entered the
+// renewal window.
+func (r *SSHKeyHostCertificateResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to renew on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan *SSHKeyHostCertificateResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.EarlyRenewalHours.IsUnknown() {
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, state.ValidityEndTime.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("validity_end_time"), "Invalid validity end time", err.Error())
+
+		return
+	}
+
+	earlyRenewal := time.Duration(plan.EarlyRenewalHours.ValueInt64()) * time.Hour
+	if time.Now().Before(end.Add(-earlyRenewal)) {
+		return
+	}
+
+	tflog.Info(ctx, "Certificate is ready for renewal")
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ready_for_renewal"), types.BoolValue(true))...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("ready_for_renewal"))
+}
+
+func (r *SSHKeyHostCertificateResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data *SSHKeyHostCertificateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	start := time.Now().UTC().Truncate(time.Second)
+	end := start.Add(time.Duration(data.ValidityPeriodHours.ValueInt64()) * time.Hour)
+
+	conf := keygen.CertificateConfig{
+		CertType:    ssh.HostCert,
+		PublicKey:   []byte(data.PublicKey.ValueString()),
+		KeyID:       data.KeyID.ValueString(),
+		Serial:      uint64(data.Serial.ValueInt64()), //nolint:gosec
+		ValidAfter:  start,
+		ValidBefore: end,
+	}
+
+	resp.Diagnostics.Append(data.Principals.ElementsAs(ctx, &conf.Principals, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert := signCertificate(data.CAPrivateKey, data.CAPrivateKeyPassphrase, &conf, &resp.Diagnostics)
+	if cert == nil {
+		return
+	}
+
+	data.ID = types.StringValue(ssh.FingerprintSHA256(cert.Cert))
+	data.Certificate = types.StringValue(string(cert.Marshal()))
+	data.CAPublicKey = types.StringValue(caPublicKey(cert))
+	data.ValidityStartTime = types.StringValue(start.Format(time.RFC3339))
+	data.ValidityEndTime = types.StringValue(end.Format(time.RFC3339))
+	data.ReadyForRenewal = types.BoolValue(false)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// no need to support Read at the moment since the resource is fully within state.
+// Renewal is handled in ModifyPlan.
+func (r *SSHKeyHostCertificateResource) Read(
+	_ context.Context,
+	_ resource.ReadRequest,
+	_ *resource.ReadResponse,
+) {
+}
+
+// Update only persists changes to early_renewal_hours, all other attributes
+// require replacement.
+func (r *SSHKeyHostCertificateResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data *SSHKeyHostCertificateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyHostCertificateResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	resp.State.RemoveResource(ctx)
+}
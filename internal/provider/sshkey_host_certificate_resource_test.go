@@ -0,0 +1,107 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAccSSHKeyHostCertificateResource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSSHKeyHostCertificateResourceConfig(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"sshkey_host_certificate.test",
+						"certificate",
+						regexp.MustCompile(`^ssh-ed25519-cert-v01@openssh.com `),
+					),
+					resource.TestCheckResourceAttr("sshkey_host_certificate.test", "ready_for_renewal", "false"),
+					resource.TestCheckResourceAttrSet("sshkey_host_certificate.test", "validity_start_time"),
+					resource.TestCheckResourceAttrSet("sshkey_host_certificate.test", "validity_end_time"),
+					resource.TestCheckResourceAttrWith("sshkey_host_certificate.test", "certificate", testCheckHostCertificate),
+				),
+			},
+			// Entering the renewal window replaces the certificate
+			{
+				Config: testAccSSHKeyHostCertificateResourceConfig(2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sshkey_host_certificate.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sshkey_host_certificate.test", "ready_for_renewal", "false"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccSSHKeyHostCertificateResourceConfig(earlyRenewalHours int) string {
+	return fmt.Sprintf(`
+resource "sshkey_pair" "ca" {
+  type = "ecdsa"
+}
+
+resource "sshkey_pair" "host" {
+  type = "ed25519"
+}
+
+resource "sshkey_host_certificate" "test" {
+  ca_private_key        = sshkey_pair.ca.private_key
+  public_key            = sshkey_pair.host.public_key
+  key_id                = "bastion"
+  principals            = ["bastion.example.com", "10.0.0.1"]
+  validity_period_hours = 1
+  early_renewal_hours   = %[1]d
+}
+`, earlyRenewalHours)
+}
+
+func testCheckHostCertificate(value string) error {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(value))
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate: %w", err)
+	}
+
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return fmt.Errorf("expected a certificate, got %T", pub)
+	}
+
+	if cert.CertType != ssh.HostCert {
+		return fmt.Errorf("unexpected certificate type %d", cert.CertType)
+	}
+
+	if cert.ValidBefore-cert.ValidAfter != 3600 {
+		return fmt.Errorf("unexpected validity period %d", cert.ValidBefore-cert.ValidAfter)
+	}
+
+	return nil
+}
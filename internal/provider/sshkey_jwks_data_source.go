@@ -0,0 +1,135 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeyJWKSDataSource{}
+
+func NewSSHKeyJWKSDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeyJWKSDataSource{}
+}
+
+// SSHKeyJWKSDataSource defines the data source implementation.
+type SSHKeyJWKSDataSource struct{}
+
+// SSHKeyJWKSDataSourceModel describes the data source data model.
+type SSHKeyJWKSDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	PublicKeys []string     `tfsdk:"public_keys"`
+	JSON       types.String `tfsdk:"json"`
+}
+
+func (d *SSHKeyJWKSDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_jwks"
+}
+
+func (d *SSHKeyJWKSDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Aggregates OpenSSH public keys into a JSON Web Key Set",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 checksum of the JSON Web Key Set",
+			},
+			"public_keys": schema.ListAttribute{
+				Description:         "OpenSSH public keys",
+				MarkdownDescription: "OpenSSH public keys in `authorized_keys` format, e.g. `sshkey_pair.example.public_key`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"json": schema.StringAttribute{
+				Description: "JSON Web Key Set",
+				MarkdownDescription: "JSON Web Key Set (RFC 7517) of the public keys. " +
+					"The key IDs are the SHA256 fingerprints of the keys.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeyJWKSDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeyJWKSDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := make([]*keygen.PublicKey, 0, len(data.PublicKeys))
+
+	for i, publicKey := range data.PublicKeys {
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("public_keys").AtListIndex(i), "Unable to parse public key", err.Error())
+
+			continue
+		}
+
+		keys = append(keys, pubKey)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwks, err := keygen.MarshalJWKS(keys)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create JSON Web Key Set", err.Error())
+
+		return
+	}
+
+	checksum := sha256.Sum256(jwks)
+
+	data.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	data.JSON = types.StringValue(string(jwks))
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
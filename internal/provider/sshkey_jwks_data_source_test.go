@@ -0,0 +1,104 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSSHKeyJWKSDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "ed25519" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "ecdsa" {
+  type  = "ecdsa"
+  curve = "P256"
+}
+
+data "sshkey_jwks" "test" {
+  public_keys = [
+    sshkey_pair.ed25519.public_key,
+    sshkey_pair.ecdsa.public_key,
+  ]
+}
+
+output "kids" {
+  value = join(",", jsondecode(data.sshkey_jwks.test.json).keys[*].kid)
+}
+
+output "expected_kids" {
+  value = join(",", [
+    trimprefix(sshkey_pair.ed25519.fingerprint_sha256, "SHA256:"),
+    trimprefix(sshkey_pair.ecdsa.fingerprint_sha256, "SHA256:"),
+  ])
+}
+
+output "public_key_jwk" {
+  value = jsondecode(data.sshkey_jwks.test.json).keys[1] == jsondecode(sshkey_pair.ecdsa.public_key_jwk)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.sshkey_jwks.test", "json",
+						regexp.MustCompile(`^\{"keys":\[\{"kty":"OKP",.*\},\{"kty":"EC",.*"crv":"P-256",.*\}\]\}$`),
+					),
+					resource.TestCheckOutput("public_key_jwk", "true"),
+					func(s *terraform.State) error {
+						outputs := s.RootModule().Outputs
+						if outputs["kids"].Value != outputs["expected_kids"].Value {
+							return fmt.Errorf("unexpected key IDs %v", outputs["kids"].Value)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyJWKSDataSourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sshkey_jwks" "test" {
+  public_keys = ["ssh-ed25519 invalid"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse public key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeyKnownHostsDataSource{}
+
+func NewSSHKeyKnownHostsDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeyKnownHostsDataSource{}
+}
+
+// SSHKeyKnownHostsDataSource defines the data source implementation.
+type SSHKeyKnownHostsDataSource struct{}
+
+// SSHKeyKnownHostsDataSourceModel describes the data source data model.
+type SSHKeyKnownHostsDataSourceModel struct {
+	ID            types.String                `tfsdk:"id"`
+	Hosts         []SSHKeyKnownHostEntryModel `tfsdk:"hosts"`
+	HashHostnames types.Bool                  `tfsdk:"hash_hostnames"`
+	KnownHosts    types.String                `tfsdk:"known_hosts"`
+}
+
+// SSHKeyKnownHostEntryModel describes a host entry of the data source.
+type SSHKeyKnownHostEntryModel struct {
+	Hostnames []string     `tfsdk:"hostnames"`
+	Port      types.Int64  `tfsdk:"port"`
+	PublicKey string       `tfsdk:"public_key"`
+	Marker    types.String `tfsdk:"marker"`
+}
+
+func (d *SSHKeyKnownHostsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_known_hosts"
+}
+
+//
+//nolint:funlen
+func (d *SSHKeyKnownHostsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders an OpenSSH `known_hosts` file from host keys",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 checksum of the known_hosts file",
+			},
+			"hosts": schema.ListNestedAttribute{
+				Description:         "Hosts and their public keys",
+				MarkdownDescription: "Hosts and their public keys, rendered as one line each in the given order",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hostnames": schema.ListAttribute{
+							Description: "Host names, addresses or patterns",
+							MarkdownDescription: "Host names, IP addresses or patterns like `*.example.com` " +
+								"the key is valid for",
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1), knownHost()),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Description:         "SSH port of the hosts",
+							MarkdownDescription: "SSH port of the hosts, written as `[host]:port` unless it is `22` (default: `22`)",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535), //nolint:mnd
+							},
+						},
+						"public_key": schema.StringAttribute{
+							Description: "OpenSSH public key of the hosts",
+							MarkdownDescription: "OpenSSH public key of the hosts in `authorized_keys` format, " +
+								"e.g. `sshkey_pair.host.public_key`, or the certificate authority key for `cert-authority`",
+							Required: true,
+						},
+						"marker": schema.StringAttribute{
+							Description: "known_hosts marker",
+							MarkdownDescription: "Either `cert-authority` to trust host certificates signed by `public_key`, " +
+								"or `revoked` to reject `public_key`",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(keygen.KnownHostsMarkers...),
+							},
+						},
+					},
+				},
+			},
+			"hash_hostnames": schema.BoolAttribute{
+				Description: "Whether to hash the host names",
+				MarkdownDescription: "Whether to hash the host names like `ssh-keygen -H`, writing one line per host name. " +
+					"The salts are derived from the host names and keys, so the file is stable between runs. " +
+					"Patterns cannot be hashed (default: `false`).",
+				Optional: true,
+			},
+			"known_hosts": schema.StringAttribute{
+				Description:         "known_hosts file",
+				MarkdownDescription: "Content of the `known_hosts` file",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeyKnownHostsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeyKnownHostsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts := make([]keygen.KnownHost, 0, len(data.Hosts))
+
+	for i, host := range data.Hosts {
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(host.PublicKey))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hosts").AtListIndex(i).AtName("public_key"),
+				"Unable to parse public key",
+				err.Error(),
+			)
+
+			continue
+		}
+
+		hosts = append(hosts, keygen.KnownHost{
+			Hosts:  host.Hostnames,
+			Port:   int(host.Port.ValueInt64()),
+			Key:    pubKey,
+			Marker: host.Marker.ValueString(),
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	knownHosts, err := keygen.MarshalKnownHosts(hosts, data.HashHostnames.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create known_hosts", err.Error())
+
+		return
+	}
+
+	checksum := sha256.Sum256(knownHosts)
+
+	data.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	data.KnownHosts = types.StringValue(string(knownHosts))
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
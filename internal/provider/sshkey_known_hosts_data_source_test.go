@@ -0,0 +1,141 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSSHKeyKnownHostsDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "host" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+data "sshkey_known_hosts" "test" {
+  hosts = [
+    {
+      hostnames  = ["web.example.com", "192.0.2.10"]
+      port       = 2222
+      public_key = sshkey_pair.host.public_key
+    },
+    {
+      hostnames  = ["*.example.com"]
+      public_key = sshkey_pair.ca.public_key
+      marker     = "cert-authority"
+    },
+  ]
+}
+
+data "sshkey_known_hosts" "hashed" {
+  hash_hostnames = true
+
+  hosts = [
+    {
+      hostnames  = ["web.example.com", "192.0.2.10"]
+      public_key = sshkey_pair.host.public_key
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.sshkey_known_hosts.test", "known_hosts",
+						regexp.MustCompile(`^\[web\.example\.com\]:2222,\[192\.0\.2\.10\]:2222 ssh-ed25519 \S+\n`+
+							`@cert-authority \*\.example\.com ssh-ed25519 \S+\n$`),
+					),
+					resource.TestMatchResourceAttr(
+						"data.sshkey_known_hosts.hashed", "known_hosts",
+						regexp.MustCompile(`^(\|1\|[A-Za-z0-9+/=]{28}\|[A-Za-z0-9+/=]{28} ssh-ed25519 \S+\n){2}$`),
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyKnownHostsDataSourceHashedPattern(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+data "sshkey_known_hosts" "test" {
+  hash_hostnames = true
+
+  hosts = [
+    {
+      hostnames  = ["*.example.com"]
+      public_key = sshkey_pair.ca.public_key
+      marker     = "cert-authority"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`host patterns cannot be hashed`),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyKnownHostsDataSourceInvalidHost(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "host" {
+  type = "ed25519"
+}
+
+data "sshkey_known_hosts" "test" {
+  hosts = [
+    {
+      hostnames  = ["web.example.com\n@cert-authority *"]
+      public_key = sshkey_pair.host.public_key
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid known_hosts host`),
+			},
+		},
+	})
+}
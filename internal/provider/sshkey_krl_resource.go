@@ -0,0 +1,380 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &SSHKeyKRLResource{}
+	_ resource.ResourceWithModifyPlan = &SSHKeyKRLResource{}
+)
+
+func NewSSHKeyKRLResource() resource.Resource { //nolint:ireturn
+	return &SSHKeyKRLResource{}
+}
+
+// SSHKeyKRLResource defines the resource implementation.
+type SSHKeyKRLResource struct{}
+
+// SSHKeyKRLResourceModel describes the resource data model.
+type SSHKeyKRLResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Comment            types.String `tfsdk:"comment"`
+	PublicKeys         types.Set    `tfsdk:"public_keys"`
+	SHA256Fingerprints types.Set    `tfsdk:"sha256_fingerprints"`
+	CAPublicKey        types.String `tfsdk:"ca_public_key"`
+	Serials            types.Set    `tfsdk:"serials"`
+	SerialRanges       types.List   `tfsdk:"serial_ranges"`
+	KeyIDs             types.Set    `tfsdk:"key_ids"`
+	Version            types.Int64  `tfsdk:"version"`
+	GeneratedAt        types.String `tfsdk:"generated_at"`
+	KRLBase64          types.String `tfsdk:"krl_base64"`
+}
+
+// SSHKeyKRLSerialRangeModel describes a range of revoked certificate serials.
+type SSHKeyKRLSerialRangeModel struct {
+	Min types.Int64 `tfsdk:"min"`
+	Max types.Int64 `tfsdk:"max"`
+}
+
+func (r *SSHKeyKRLResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_krl"
+}
+
+//
+//nolint:funlen
+func (r *SSHKeyKRLResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OpenSSH key revocation list (KRL) for `RevokedKeys` in `sshd_config`. " +
+			"Changes update the KRL in place and increase its version.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 checksum of the KRL",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description:         "KRL comment",
+				MarkdownDescription: "Comment stored in the KRL, shown by `ssh-keygen -Q -l`",
+				Optional:            true,
+			},
+			"public_keys": schema.SetAttribute{
+				Description: "Revoked OpenSSH public keys",
+				MarkdownDescription: "Revoked OpenSSH public keys in `authorized_keys` format. " +
+					"Certificates are revoked by `serials` or `key_ids`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"sha256_fingerprints": schema.SetAttribute{
+				Description:         "SHA256 fingerprints of revoked keys",
+				MarkdownDescription: "SHA256 fingerprints of revoked keys, e.g. `SHA256:...` as shown by `ssh-keygen -l`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ca_public_key": schema.StringAttribute{
+				Description: "OpenSSH public key of the certificate authority",
+				MarkdownDescription: "OpenSSH public key of the certificate authority which issued the revoked certificates. " +
+					"Without it, `key_ids` are revoked for certificates of any authority.",
+				Optional: true,
+			},
+			"serials": schema.SetAttribute{
+				Description:         "Revoked certificate serials",
+				MarkdownDescription: "Serial numbers of revoked certificates issued by `ca_public_key`",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+					setvalidator.AlsoRequires(path.MatchRoot("ca_public_key")),
+				},
+			},
+			"serial_ranges": schema.ListNestedAttribute{
+				Description:         "Revoked certificate serial ranges",
+				MarkdownDescription: "Inclusive ranges of serial numbers of revoked certificates issued by `ca_public_key`",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRoot("ca_public_key")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"min": schema.Int64Attribute{
+							Description:         "First revoked serial",
+							MarkdownDescription: "First revoked serial",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"max": schema.Int64Attribute{
+							Description:         "Last revoked serial",
+							MarkdownDescription: "Last revoked serial",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min")),
+							},
+						},
+					},
+				},
+			},
+			"key_ids": schema.SetAttribute{
+				Description:         "Revoked certificate key IDs",
+				MarkdownDescription: "Key identifiers of revoked certificates, e.g. the `key_id` of `sshkey_user_certificate`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"version": schema.Int64Attribute{
+				Description:         "KRL version",
+				MarkdownDescription: "KRL version, starting at `1` and increased with every change",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"generated_at": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp of the KRL generation",
+				MarkdownDescription: "RFC 3339 timestamp of the KRL generation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"krl_base64": schema.StringAttribute{
+				Description: "Base64 encoded binary KRL",
+				MarkdownDescription: "Base64 encoded binary KRL, e.g. for the `content_base64` of a `local_file` " +
+					"referenced by `RevokedKeys`",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSHKeyKRLResource) Configure(
+	_ context.Context,
+	_ resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+}
+
+// ModifyPlan increases the version of an existing KRL when its revocations
+// change.
+func (r *SSHKeyKRLResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to bump on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, state *SSHKeyKRLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || !data.changed(state) {
+		return
+	}
+
+	data.ID = types.StringUnknown()
+	data.Version = types.Int64Value(state.Version.ValueInt64() + 1)
+	data.GeneratedAt = types.StringUnknown()
+	data.KRLBase64 = types.StringUnknown()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyKRLResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data *SSHKeyKRLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Version = types.Int64Value(1)
+	data.generate(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// no need to support Read at the moment since the resource is fully within state.
+func (r *SSHKeyKRLResource) Read(
+	_ context.Context,
+	_ resource.ReadRequest,
+	_ *resource.ReadResponse,
+) {
+}
+
+// Update regenerates the KRL with the version planned by ModifyPlan.
+func (r *SSHKeyKRLResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data *SSHKeyKRLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.generate(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyKRLResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	resp.State.RemoveResource(ctx)
+}
+
+// changed reports whether the revocations or the comment differ from state.
+func (m *SSHKeyKRLResourceModel) changed(state *SSHKeyKRLResourceModel) bool {
+	return !m.Comment.Equal(state.Comment) ||
+		!m.PublicKeys.Equal(state.PublicKeys) ||
+		!m.SHA256Fingerprints.Equal(state.SHA256Fingerprints) ||
+		!m.CAPublicKey.Equal(state.CAPublicKey) ||
+		!m.Serials.Equal(state.Serials) ||
+		!m.SerialRanges.Equal(state.SerialRanges) ||
+		!m.KeyIDs.Equal(state.KeyIDs)
+}
+
+// generate builds the KRL of the configured revocations.
+func (m *SSHKeyKRLResourceModel) generate(ctx context.Context, diags *diag.Diagnostics) {
+	var (
+		publicKeys []string
+		serials    []int64
+		ranges     []SSHKeyKRLSerialRangeModel
+	)
+
+	now := time.Now().UTC()
+	conf := keygen.KRLConfig{
+		Version:     uint64(m.Version.ValueInt64()), //nolint:gosec
+		Comment:     m.Comment.ValueString(),
+		GeneratedAt: now,
+	}
+
+	diags.Append(m.PublicKeys.ElementsAs(ctx, &publicKeys, false)...)
+	diags.Append(m.SHA256Fingerprints.ElementsAs(ctx, &conf.SHA256Fingerprints, false)...)
+	diags.Append(m.Serials.ElementsAs(ctx, &serials, false)...)
+	diags.Append(m.SerialRanges.ElementsAs(ctx, &ranges, false)...)
+	diags.Append(m.KeyIDs.ElementsAs(ctx, &conf.KeyIDs, false)...)
+
+	for _, publicKey := range publicKeys {
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+		if err != nil {
+			diags.AddAttributeError(path.Root("public_keys"), "Unable to parse public key", err.Error())
+
+			continue
+		}
+
+		conf.Keys = append(conf.Keys, pubKey)
+	}
+
+	if !m.CAPublicKey.IsNull() {
+		caKey, err := keygen.ParseAuthorizedKey([]byte(m.CAPublicKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_public_key"), "Unable to parse public key", err.Error())
+		}
+
+		conf.CAKey = caKey
+	}
+
+	for _, serial := range serials {
+		conf.Serials = append(conf.Serials, uint64(serial)) //nolint:gosec
+	}
+
+	for _, serialRange := range ranges {
+		conf.SerialRanges = append(conf.SerialRanges, keygen.KRLSerialRange{
+			Min: uint64(serialRange.Min.ValueInt64()), //nolint:gosec
+			Max: uint64(serialRange.Max.ValueInt64()), //nolint:gosec
+		})
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	krl, err := keygen.MarshalKRL(&conf)
+	if err != nil {
+		diags.AddError("Unable to create KRL", err.Error())
+
+		return
+	}
+
+	checksum := sha256.Sum256(krl)
+
+	m.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	m.GeneratedAt = types.StringValue(now.Format(time.RFC3339))
+	m.KRLBase64 = types.StringValue(base64.StdEncoding.EncodeToString(krl))
+}
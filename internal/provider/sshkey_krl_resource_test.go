@@ -0,0 +1,135 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccSSHKeyKRLResource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSSHKeyKRLResourceConfig(`[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sshkey_krl.test", "version", "1"),
+					resource.TestCheckResourceAttrWith("sshkey_krl.test", "krl_base64", testCheckKRL),
+				),
+			},
+			// Revoke a certificate by key ID
+			{
+				Config: testAccSSHKeyKRLResourceConfig(`["jane"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sshkey_krl.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sshkey_krl.test", "version", "2"),
+					resource.TestCheckResourceAttrWith("sshkey_krl.test", "krl_base64", testCheckKRL),
+				),
+			},
+			{
+				Config: testAccSSHKeyKRLResourceConfig(`["jane"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccSSHKeyKRLResourceConfig(keyIDs string) string {
+	return fmt.Sprintf(`
+resource "sshkey_pair" "leaked" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_krl" "test" {
+  comment             = "revoked keys"
+  public_keys         = [sshkey_pair.leaked.public_key]
+  sha256_fingerprints = [sshkey_pair.ca.fingerprint_sha256]
+  ca_public_key       = sshkey_pair.ca.public_key
+  serials             = [1, 5]
+  key_ids             = %s
+
+  serial_ranges = [
+    {
+      min = 100
+      max = 200
+    },
+  ]
+}
+`, keyIDs)
+}
+
+// testCheckKRL ensures that the value is a base64 encoded KRL.
+func testCheckKRL(value string) error {
+	krl, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(krl, []byte("SSHKRL\n\x00")) {
+		return fmt.Errorf("missing KRL magic in %q", krl)
+	}
+
+	return nil
+}
+
+func TestAccSSHKeyKRLResourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_krl" "test" {
+  serials = [1]
+}
+`,
+				ExpectError: regexp.MustCompile(`Attribute "ca_public_key" must be specified`),
+			},
+			{
+				Config: `
+resource "sshkey_krl" "test" {
+  sha256_fingerprints = ["MD5:00:11"]
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid SHA256 fingerprint`),
+			},
+		},
+	})
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &SSHKeyPairEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &SSHKeyPairEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &SSHKeyPairEphemeralResource{}
+)
+
+func NewSSHKeyPairEphemeralResource() ephemeral.EphemeralResource { //nolint:ireturn
+	return &SSHKeyPairEphemeralResource{defaults: &SSHKeyProviderData{}}
+}
+
+// SSHKeyPairEphemeralResource defines the ephemeral resource implementation.
+type SSHKeyPairEphemeralResource struct {
+	defaults *SSHKeyProviderData
+}
+
+// SSHKeyPairEphemeralResourceModel describes the ephemeral resource data model.
+type SSHKeyPairEphemeralResourceModel struct {
+	Type       types.String `tfsdk:"type"`
+	Bits       types.Int64  `tfsdk:"bits"`
+	Curve      types.String `tfsdk:"curve"`
+	Comment    types.String `tfsdk:"comment"`
+	Passphrase types.String `tfsdk:"passphrase"`
+	SSHKeyPairPrivateKeysModel
+	PublicKeyJWK      types.String `tfsdk:"public_key_jwk"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (r *SSHKeyPairEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_pair"
+}
+
+//
+//nolint:funlen
+func (r *SSHKeyPairEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSH key pair which is generated on every run and never stored in state",
+
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "SSH key type",
+				MarkdownDescription: keyTypeDescription() + " Defaults to the provider `default_type`, " +
+					"which is required when `type` is not set.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(keyTypeStrings()...),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				Description:         "Size of the key in bits",
+				MarkdownDescription: keyBitsDescription(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(keygen.KeySizes()...),
+				},
+			},
+			"curve": schema.StringAttribute{
+				Description:         "ECDSA curve",
+				MarkdownDescription: keyCurveDescription(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(curveStrings()...),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description:         "SSH key comment",
+				MarkdownDescription: "SSH key comment (default: the provider `default_comment` or `user@hostname`)",
+				Optional:            true,
+			},
+			"passphrase": schema.StringAttribute{
+				Description:         "Passphrase used to encrypt the private key",
+				MarkdownDescription: "Passphrase used to encrypt the private key",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Description:         "Private key in OpenSSH format",
+				MarkdownDescription: "Private key in OpenSSH format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"private_key_openssh": schema.StringAttribute{
+				Description:         "OpenSSH private key",
+				MarkdownDescription: "Private key in OpenSSH format, the same as `private_key`",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "Private key in traditional PEM format",
+				MarkdownDescription: "Private key in traditional PEM format, i.e. PKCS#1 for `rsa` and SEC1 for `ecdsa` keys. " +
+					"Not available for `ed25519` keys and when a passphrase is set, use `private_key_pem_pkcs8` for encrypted keys.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"private_key_pem_pkcs8": schema.StringAttribute{
+				Description:         "Private key in PKCS#8 PEM format",
+				MarkdownDescription: "Private key in PKCS#8 PEM format, encrypted with PBES2 when a passphrase is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"private_key_putty": schema.StringAttribute{
+				Description:         "Private key in PuTTY format",
+				MarkdownDescription: "Private key in PuTTY `.ppk` format, for use with PuTTY and WinSCP",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"private_key_jwk": schema.StringAttribute{
+				Description: "Private key as JSON Web Key",
+				MarkdownDescription: "Unencrypted private key as JSON Web Key (RFC 7517), the key ID is the SHA256 fingerprint. " +
+					"Not available when a passphrase is set",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"putty_version": schema.Int64Attribute{
+				Description: "Version of the PuTTY private key format",
+				MarkdownDescription: "Version of the PuTTY private key format. Version `3` uses Argon2 key derivation and " +
+					"needs PuTTY 0.75 or newer, version `2` is understood by older releases (default: `3`).",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(keygen.PPKVersions...),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key",
+				MarkdownDescription: "OpenSSH public key",
+				Computed:            true,
+			},
+			"public_key_jwk": schema.StringAttribute{
+				Description:         "Public key as JSON Web Key",
+				MarkdownDescription: "Public key as JSON Web Key (RFC 7517), the key ID is the SHA256 fingerprint",
+				Computed:            true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				Description:         "OpenSSH key md5 fingerprint",
+				MarkdownDescription: "OpenSSH key md5 fingerprint",
+				Computed:            true,
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Description:         "OpenSSH key sha256 fingerprint",
+				MarkdownDescription: "OpenSSH key sha256 fingerprint",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SSHKeyPairEphemeralResource) ValidateConfig(
+	ctx context.Context,
+	req ephemeral.ValidateConfigRequest,
+	resp *ephemeral.ValidateConfigResponse,
+) {
+	var data SSHKeyPairEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKeyParameters(data.Type, data.Bits, data.Curve)...)
+}
+
+func (r *SSHKeyPairEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	defaults, err := providerData(req.ProviderData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Ephemeral Resource Configure Type", err.Error())
+
+		return
+	}
+
+	r.defaults = defaults
+}
+
+func (r *SSHKeyPairEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var data *SSHKeyPairEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bitsValue := data.Bits.ValueInt64()
+
+	if bitsValue < 0 || bitsValue > math.MaxUint16 {
+		resp.Diagnostics.AddError("Invalid bits value", "Bits value must be between 0 and 65535")
+
+		return
+	}
+
+	conf := keygen.SSHKeyPairConfig{
+		Passphrase: []byte(data.Passphrase.ValueString()),
+		Type:       keygen.KeyType(data.Type.ValueString()),
+		Bits:       uint16(bitsValue),
+		Curve:      keygen.Curve(data.Curve.ValueString()),
+		Comment:    data.Comment.ValueString(),
+	}
+
+	r.defaults.applyDefaults(&conf)
+
+	if conf.Type == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Missing key type",
+			"type must be set when the provider does not configure default_type.",
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(validateKeyParameters(
+		types.StringValue(string(conf.Type)), data.Bits, optionalString(string(conf.Curve)),
+	)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sshkey, err := keygen.New(&conf)
+	if err != nil {
+		resp.Diagnostics.AddError("Key generation failed", err.Error())
+
+		return
+	}
+
+	if data.Comment.IsNull() {
+		if sshkey.Comment, err = r.defaults.comment(sshkey, nil); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("comment"), "Invalid default comment", err.Error())
+
+			return
+		}
+	}
+
+	data.Type = types.StringValue(string(sshkey.Type))
+
+	data.Bits = types.Int64Value(int64(sshkey.Bits))
+
+	data.Curve = optionalString(string(sshkey.Curve))
+
+	if data.PuTTYVersion.IsNull() {
+		data.PuTTYVersion = types.Int64Value(int64(keygen.PPKDefaultVersion))
+	}
+
+	data.setPrivateKeys(sshkey)
+
+	data.PublicKey = types.StringValue(string(sshkey.PublicKey()))
+	data.PublicKeyJWK = types.StringValue(string(sshkey.PublicKeyJWK()))
+	data.FingerprintMD5 = types.StringValue(sshkey.MD5())
+	data.FingerprintSHA256 = types.StringValue(sshkey.SHA256())
+
+	tflog.Trace(ctx, "opened an ephemeral resource")
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/jlec/terraform-provider-sshkey/internal/provider"
+)
+
+// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider, which
+// copies ephemeral values into state so they can be checked.
+//
+//nolint:gochecknoglobals
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"sshkey": providerserver.NewProtocol6WithError(provider.New("test")()),
+	"echo":   echoprovider.NewProviderServer(),
+}
+
+func TestAccSSHKeyPairEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "sshkey_pair" "test" {
+  type       = "ecdsa"
+  curve      = "P256"
+  comment    = "ci@example.com"
+  passphrase = "secret"
+}
+
+provider "echo" {
+  data = {
+    bits       = ephemeral.sshkey_pair.test.bits
+    curve      = ephemeral.sshkey_pair.test.curve
+    public_key = ephemeral.sshkey_pair.test.public_key
+    decrypted = nonsensitive(
+      provider::sshkey::public_key_from_private(ephemeral.sshkey_pair.test.private_key, "secret")
+    ) == ephemeral.sshkey_pair.test.public_key
+  }
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("bits"), knownvalue.Int64Exact(256)),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("curve"), knownvalue.StringExact("P256")),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("public_key"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^ecdsa-sha2-nistp256 \S+ ci@example.com$`)),
+					),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("decrypted"), knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyPairEphemeralResourceInvalidCurve(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "sshkey_pair" "test" {
+  type  = "ed25519"
+  curve = "P256"
+}
+`,
+				ExpectError: regexp.MustCompile(`curve can only be set when type is "ecdsa"`),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyPairEphemeralResourceProviderDefaults(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "sshkey" {
+  default_type        = "ecdsa"
+  default_ecdsa_curve = "P521"
+  default_comment     = "ci-{{.Type}}"
+}
+
+ephemeral "sshkey_pair" "test" {}
+
+provider "echo" {
+  data = {
+    type       = ephemeral.sshkey_pair.test.type
+    curve      = ephemeral.sshkey_pair.test.curve
+    public_key = ephemeral.sshkey_pair.test.public_key
+  }
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("type"), knownvalue.StringExact("ecdsa")),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("curve"), knownvalue.StringExact("P521")),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("public_key"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^ecdsa-sha2-nistp521 \S+ ci-ecdsa$`)),
+					),
+				},
+			},
+		},
+	})
+}
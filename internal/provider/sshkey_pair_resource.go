@@ -17,50 +17,83 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"math"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
 )
 
+// errImportEnvNotSet indicates that the environment variable referenced by the
+// import identifier is not set.
+var errImportEnvNotSet = errors.New("environment variable not set")
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &SSHKeyPairResource{}
-	_ resource.ResourceWithImportState = &SSHKeyPairResource{}
+	_ resource.Resource                   = &SSHKeyPairResource{}
+	_ resource.ResourceWithImportState    = &SSHKeyPairResource{}
+	_ resource.ResourceWithModifyPlan     = &SSHKeyPairResource{}
+	_ resource.ResourceWithValidateConfig = &SSHKeyPairResource{}
 )
 
-const (
-	sshKeyPath string = "/dev/null"
-	sshKeyPass string = ""
-)
+// importPassphraseEnv names the environment variable holding the passphrase
+// of an encrypted private key during import.
+const importPassphraseEnv = "SSHKEY_IMPORT_PASSPHRASE"
 
 func NewSSHKeyPairResource() resource.Resource { //nolint:ireturn
-	return &SSHKeyPairResource{}
+	return &SSHKeyPairResource{defaults: &SSHKeyProviderData{}}
 }
 
 // SSHKeyPairResource defines the resource implementation.
-type SSHKeyPairResource struct{}
+type SSHKeyPairResource struct {
+	defaults *SSHKeyProviderData
+}
 
 // SSHKeyPairResourceModel describes the resource data model.
 type SSHKeyPairResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Type              types.String `tfsdk:"type"`
-	Bits              types.Int64  `tfsdk:"bits"`
-	Comment           types.String `tfsdk:"comment"`
-	PrivateKeyPEM     types.String `tfsdk:"private_key"`
-	PublicKey         types.String `tfsdk:"public_key"`
-	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
-	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+	ID        types.String `tfsdk:"id"`
+	Type      types.String `tfsdk:"type"`
+	Bits      types.Int64  `tfsdk:"bits"`
+	Curve     types.String `tfsdk:"curve"`
+	Comment   types.String `tfsdk:"comment"`
+	Keepers   types.Map    `tfsdk:"keepers"`
+	Seed      types.String `tfsdk:"seed"`
+	SeedLabel types.String `tfsdk:"seed_label"`
+	SSHKeyPairPrivateKeysModel
+	PublicKeyJWK         types.String `tfsdk:"public_key_jwk"`
+	PublicKey            types.String `tfsdk:"public_key"`
+	FingerprintMD5       types.String `tfsdk:"fingerprint_md5"`
+	FingerprintSHA256    types.String `tfsdk:"fingerprint_sha256"`
+	SSHFPRecords         types.List   `tfsdk:"sshfp_records"`
+	Passphrase           types.String `tfsdk:"passphrase"`
+	PassphraseWO         types.String `tfsdk:"passphrase_wo"`
+	PassphraseWOVersion  types.Int64  `tfsdk:"passphrase_wo_version"`
+	PreviousPassphraseWO types.String `tfsdk:"previous_passphrase_wo"`
+	RotationDays         types.Int64  `tfsdk:"rotation_days"`
+	RotationRFC3339      types.String `tfsdk:"rotation_rfc3339"`
+	CreatedAt            types.String `tfsdk:"created_at"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
 }
 
 func (r *SSHKeyPairResource) Metadata(
@@ -91,65 +124,345 @@ func (r *SSHKeyPairResource) Schema(
 				},
 			},
 			"comment": schema.StringAttribute{
-				Description:         "SSH key comment",
-				MarkdownDescription: "SSH key comment",
+				Description: "SSH key comment",
+				MarkdownDescription: "SSH key comment (default: the provider `default_comment` or `user@hostname`). " +
+					"Changing it updates the existing key in place, removing it keeps the current comment.",
+				Optional: true,
+			},
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary values that trigger a new key pair when changed",
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger a new key pair " +
+					"to be generated, e.g. the ID of the instance the key is provisioned onto.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"seed": schema.StringAttribute{
+				Description: "Secret seed the key is derived from",
+				MarkdownDescription: "Secret seed the key is derived from instead of generating a random key. " +
+					"The same `seed`, `type`, `curve` and `seed_label` always yield the same key, which is meant for " +
+					"reproducible test environments. Only `ed25519` and `ecdsa` keys can be derived. " +
+					"The key material is HKDF-SHA256 output with the seed as input key material, the salt " +
+					"`terraform-provider-sshkey/v1` and the info `<type>:<curve>:<seed_label>`.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"seed_label": schema.StringAttribute{
+				Description:         "Label distinguishing the keys derived from the same seed",
+				MarkdownDescription: "Label distinguishing the keys derived from the same `seed`, e.g. the host name",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("seed")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"type": schema.StringAttribute{
-				Description:         "SSH key type",
-				MarkdownDescription: "SSH key type. Supported types are `rsa` and `ed25519`.",
-				Required:            true,
+				Description: "SSH key type",
+				MarkdownDescription: keyTypeDescription() + " Defaults to the provider `default_type`, " +
+					"which is required when `type` is not set.",
+				Optional: true,
+				Computed: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(keygen.SSHKeyTypesStrings...),
+					stringvalidator.OneOf(keyTypeStrings()...),
 				},
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"bits": schema.Int64Attribute{
-				MarkdownDescription: "When `algorithm` is `RSA`, the size of the generated RSA key, in bits (default: `2048`).",
+				Description:         "Size of the key in bits",
+				MarkdownDescription: keyBitsDescription(),
 				Optional:            true,
 				Computed:            true,
 				Validators: []validator.Int64{
-					int64validator.OneOf(keygen.SSHRsaBits...),
+					int64validator.OneOf(keygen.KeySizes()...),
 				},
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"curve": schema.StringAttribute{
+				Description:         "ECDSA curve",
+				MarkdownDescription: keyCurveDescription(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(curveStrings()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					ecdsaCurveDefault(),
+					stringplanmodifier.RequiresReplaceIf(
+						ecdsaCurveChanged,
+						"Changing the curve of an existing key requires replacement.",
+						"Changing the curve of an existing key requires replacement.",
+					),
+				},
+			},
+			"passphrase": schema.StringAttribute{
+				Description:         "Passphrase used to encrypt the private key",
+				MarkdownDescription: "Passphrase used to encrypt the private key. Changing it re-encrypts the existing key.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("passphrase_wo")),
+				},
+			},
+			"passphrase_wo": schema.StringAttribute{
+				Description: "Write-only passphrase used to encrypt the private key",
+				MarkdownDescription: "Write-only passphrase used to encrypt the private key. It is never stored in state. " +
+					"Bump `passphrase_wo_version` to re-encrypt the existing key with a new passphrase.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"passphrase_wo_version": schema.Int64Attribute{
+				Description:         "Version of the write-only passphrase",
+				MarkdownDescription: "Version of `passphrase_wo`. Changing it re-encrypts the existing key.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("passphrase_wo")),
+				},
+			},
+			"previous_passphrase_wo": schema.StringAttribute{
+				Description: "Write-only passphrase the private key is currently encrypted with",
+				MarkdownDescription: "Write-only passphrase the private key is currently encrypted with. " +
+					"Only needed when `passphrase_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "Number of days after which the key is replaced",
+				MarkdownDescription: "Number of days after which the key is replaced. The replacement is planned by the " +
+					"first `terraform plan` after the key expired.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("rotation_rfc3339")),
+				},
+			},
+			"rotation_rfc3339": schema.StringAttribute{
+				Description: "RFC 3339 timestamp after which the key is replaced",
+				MarkdownDescription: "RFC 3339 timestamp after which the key is replaced. The replacement is planned by the " +
+					"first `terraform plan` after the key expired.",
+				Optional: true,
+				Validators: []validator.String{
+					rfc3339(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the key creation",
+				MarkdownDescription: "RFC 3339 timestamp of the key creation. Keys created or imported before " +
+					"this attribute existed adopt the time a rotation policy is added.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"expires_at": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp after which the key is replaced",
+				MarkdownDescription: "RFC 3339 timestamp after which the key is replaced, unset without rotation policy",
+				Computed:            true,
+			},
 			"private_key": schema.StringAttribute{
 				Description:         "OpenSSH private key",
-				MarkdownDescription: "OpenSSH private key",
+				MarkdownDescription: "OpenSSH private key, encrypted when a passphrase is set",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_openssh": schema.StringAttribute{
+				Description:         "OpenSSH private key",
+				MarkdownDescription: "Private key in OpenSSH format, the same as `private_key`",
 				Computed:            true,
 				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "Private key in traditional PEM format",
+				MarkdownDescription: "Private key in traditional PEM format, i.e. PKCS#1 for `rsa` and SEC1 for `ecdsa` keys. " +
+					"Not available for `ed25519` keys and when a passphrase is set, use `private_key_pem_pkcs8` for encrypted keys.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pem_pkcs8": schema.StringAttribute{
+				Description:         "Private key in PKCS#8 PEM format",
+				MarkdownDescription: "Private key in PKCS#8 PEM format, encrypted with PBES2 when a passphrase is set",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_putty": schema.StringAttribute{
+				Description:         "Private key in PuTTY format",
+				MarkdownDescription: "Private key in PuTTY `.ppk` format, for use with PuTTY and WinSCP",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_jwk": schema.StringAttribute{
+				Description: "Private key as JSON Web Key",
+				MarkdownDescription: "Unencrypted private key as JSON Web Key (RFC 7517), the key ID is the SHA256 fingerprint. " +
+					"Not available when a passphrase is set",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"putty_version": schema.Int64Attribute{
+				Description: "Version of the PuTTY private key format",
+				MarkdownDescription: "Version of the PuTTY private key format. Version `3` uses Argon2 key derivation and " +
+					"needs PuTTY 0.75 or newer, version `2` is understood by older releases (default: `3`).",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(int64(keygen.PPKDefaultVersion)),
+				Validators: []validator.Int64{
+					int64validator.OneOf(keygen.PPKVersions...),
+				},
 			},
 			"public_key": schema.StringAttribute{
 				Description:         "OpenSSH public key",
 				MarkdownDescription: "OpenSSH public key",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_jwk": schema.StringAttribute{
+				Description:         "Public key as JSON Web Key",
+				MarkdownDescription: "Public key as JSON Web Key (RFC 7517), the key ID is the SHA256 fingerprint",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"fingerprint_md5": schema.StringAttribute{
 				Description:         "OpenSSH key md5 fingerprint",
 				MarkdownDescription: "OpenSSH key md5 fingerprint",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"fingerprint_sha256": schema.StringAttribute{
 				Description:         "OpenSSH key sha256 fingerprint",
 				MarkdownDescription: "OpenSSH key sha256 fingerprint",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sshfp_records": schema.ListNestedAttribute{
+				Description: "SSHFP DNS records of the public key",
+				MarkdownDescription: "SSHFP DNS records (RFC 4255) of the public key with SHA-1 and SHA-256 fingerprints, " +
+					"like `ssh-keygen -r`, for host keys verified with `VerifyHostKeyDNS`",
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"algorithm": schema.Int64Attribute{
+							MarkdownDescription: sshfpRecordDescriptions["algorithm"],
+							Computed:            true,
+						},
+						"fingerprint_type": schema.Int64Attribute{
+							MarkdownDescription: sshfpRecordDescriptions["fingerprint_type"],
+							Computed:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: sshfpRecordDescriptions["fingerprint"],
+							Computed:            true,
+						},
+						"rdata": schema.StringAttribute{
+							MarkdownDescription: sshfpRecordDescriptions["rdata"],
+							Computed:            true,
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func (r *SSHKeyPairResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data SSHKeyPairResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKeyParameters(data.Type, data.Bits, data.Curve)...)
+	resp.Diagnostics.Append(validateSeedType(data.Type, data.Seed)...)
+}
+
+// validateSeedType ensures that seed is only configured for key types which
+// can be derived deterministically.
+func validateSeedType(keyType, seed types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if keyType.IsNull() || keyType.IsUnknown() || seed.IsNull() {
+		return diags
+	}
+
+	impl, ok := keygen.LookupKeyType(keygen.KeyType(keyType.ValueString()))
+	if ok && !impl.Deterministic {
+		diags.AddAttributeError(
+			path.Root("seed"),
+			"Invalid attribute combination",
+			"seed cannot be used with type \""+keyType.ValueString()+"\", keys of this type cannot be derived.",
+		)
+	}
+
+	return diags
+}
+
 func (r *SSHKeyPairResource) Configure(
 	_ context.Context,
-	_ resource.ConfigureRequest,
-	_ *resource.ConfigureResponse,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
 ) {
+	defaults, err := providerData(req.ProviderData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", err.Error())
+
+		return
+	}
+
+	r.defaults = defaults
 }
 
 //
@@ -172,20 +485,7 @@ func (r *SSHKeyPairResource) Create(
 		return
 	}
 
-	var ktyp keygen.KeyType
-
-	switch data.Type.ValueString() {
-	case "rsa":
-		ktyp = keygen.RSA
-	case "ed25519":
-		ktyp = keygen.ED25519
-	case "ecdsa":
-		ktyp = keygen.ECDSA
-	}
-
-	if data.Bits.IsUnknown() {
-		data.Bits = types.Int64Value(keygen.RsaDefaultBits)
-	}
+	computeBits := data.Bits.IsUnknown()
 
 	bitsValue := data.Bits.ValueInt64()
 	if bitsValue < 0 || bitsValue > math.MaxUint16 {
@@ -194,19 +494,24 @@ func (r *SSHKeyPairResource) Create(
 		return
 	}
 
-	conf := keygen.SSHKeyPairConfig{
-		Passphrase: []byte(sshKeyPass),
-		Type:       ktyp,
-		Bits:       uint16(bitsValue),
+	passphrase, diags := r.passphrase(ctx, req.Config, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if data.Comment.IsNull() {
-		conf.Comment = keygen.GetSSHKeyComment()
-	} else {
-		conf.Comment = data.Comment.String()
+	conf := keygen.SSHKeyPairConfig{
+		Passphrase: passphrase,
+		Type:       keygen.KeyType(data.Type.ValueString()),
+		Bits:       uint16(bitsValue),
+		Curve:      keygen.Curve(data.Curve.ValueString()),
+		Comment:    data.Comment.ValueString(),
+		Seed:       []byte(data.Seed.ValueString()),
+		Label:      data.SeedLabel.ValueString(),
 	}
 
-	// data.Comment = types.StringValue("bar")
+	r.defaults.applyDefaults(&conf)
 
 	if sshkey, err = keygen.New(&conf); err != nil {
 		resp.Diagnostics.AddError("Key generation failed", err.Error())
@@ -214,13 +519,39 @@ func (r *SSHKeyPairResource) Create(
 		return
 	}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
+	if data.Comment.IsNull() {
+		var keepers map[string]string
+
+		resp.Diagnostics.Append(data.Keepers.ElementsAs(ctx, &keepers, false)...)
+
+		if sshkey.Comment, err = r.defaults.comment(sshkey, keepers); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("comment"), "Invalid default comment", err.Error())
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	data.ID = types.StringValue(sshkey.SHA256())
-	data.PrivateKeyPEM = types.StringValue(string(sshkey.PrivateKeyPEM()))
+
+	if computeBits {
+		data.Bits = types.Int64Value(int64(sshkey.Bits))
+	}
+
+	data.Type = types.StringValue(string(sshkey.Type))
+	data.Curve = optionalString(string(sshkey.Curve))
+
+	now := time.Now().UTC()
+	data.CreatedAt = types.StringValue(now.Format(time.RFC3339))
+	data.ExpiresAt = data.expiresAt(now)
+
+	data.setPrivateKeys(sshkey)
 	data.PublicKey = types.StringValue(string(sshkey.PublicKey()))
+	data.PublicKeyJWK = types.StringValue(string(sshkey.PublicKeyJWK()))
 	data.FingerprintMD5 = types.StringValue(sshkey.MD5())
 	data.FingerprintSHA256 = types.StringValue(sshkey.SHA256())
+	data.SSHFPRecords = sshkeySSHFPRecords(sshkey, &resp.Diagnostics)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -230,22 +561,266 @@ func (r *SSHKeyPairResource) Create(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// no need to support Read at the moment since the resource is fully within state
-// NOTE: if we support sourcing from files on disk in the future, this will have to be implemented.
+// Read verifies the stored private key and repairs the attributes derived from
+// it, e.g. after manual state edits. A private key which cannot be read is
+// removed from state, so that the key pair is recreated. A well-formed key
+// which cannot be decrypted is an error instead, as recreating it would lose
+// the key.
 func (r *SSHKeyPairResource) Read(
-	_ context.Context,
-	_ resource.ReadRequest,
-	_ *resource.ReadResponse,
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data *SSHKeyPairResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pubKey, err := keygen.ParsePrivateKeyPublicKey(
+		[]byte(data.PrivateKeyPEM.ValueString()),
+		[]byte(data.Passphrase.ValueString()),
+	)
+	switch {
+	case errors.Is(err, x509.IncorrectPasswordError):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("passphrase"),
+			"Unable to decrypt private key",
+			"The private key in state cannot be decrypted with the passphrase in state: "+err.Error(),
+		)
+
+		return
+	case err != nil:
+		resp.Diagnostics.AddWarning(
+			"Invalid private key in state",
+			"The private key in state cannot be read and the key pair will be recreated: "+err.Error(),
+		)
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	// The comment of encrypted keys is only known to the public key.
+	if pubKey.Comment == "" {
+		pubKey.Comment = commentFromPublicKey(data.PublicKey.ValueString())
+	}
+
+	sshfp, diags := sshfpRecords(pubKey)
+	resp.Diagnostics.Append(diags...)
+
+	data.SSHFPRecords = sshfp
+
+	if repaired := data.repairPublicKey(pubKey); len(repaired) > 0 {
+		tflog.Warn(ctx, "repaired key pair state", map[string]any{"attributes": repaired})
+
+		resp.Diagnostics.AddWarning(
+			"Repaired key pair state",
+			"The attributes "+strings.Join(repaired, ", ")+" did not match the private key in state and were recomputed.",
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan applies the provider defaults to new keys and recomputes the
+// public key of existing keys when their comment changes. It also computes the
+// expiry of existing keys and plans their replacement once the rotation policy
+// expired.
+func (r *SSHKeyPairResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
 ) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		r.modifyCreatePlan(ctx, req, resp)
+
+		return
+	}
+
+	var data, state *SSHKeyPairResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateStatePassphrase(ctx, req.Config, data, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Comment.IsNull() && !data.Comment.Equal(state.Comment) {
+		data.PublicKey = types.StringUnknown()
+	}
+
+	switch {
+	case data.reencodes(state):
+		data.setPrivateKeysUnknown()
+	case !data.PuTTYVersion.Equal(state.PuTTYVersion):
+		data.PrivateKeyPuTTY = types.StringUnknown()
+	}
+
+	now := time.Now().UTC()
+
+	data.CreatedAt = state.CreatedAt
+	if data.CreatedAt.IsNull() && data.hasRotation() {
+		data.CreatedAt = types.StringValue(now.Format(time.RFC3339))
+	}
+
+	data.ExpiresAt = data.expiresAt(now)
+
+	if data.ExpiresAt.IsUnknown() || data.ExpiresAt.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid expiry timestamp", err.Error())
+
+		return
+	}
+
+	if !now.Before(expiresAt) {
+		tflog.Debug(ctx, "key expired, planning replacement", map[string]any{
+			"expires_at": data.ExpiresAt.ValueString(),
+		})
+
+		data.CreatedAt = types.StringUnknown()
+		data.ExpiresAt = types.StringUnknown()
+		resp.RequiresReplace = path.Paths{path.Root("created_at")}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// modifyCreatePlan resolves the key type and curve of a new key from the
+// configuration and the provider defaults.
+func (r *SSHKeyPairResource) modifyCreatePlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	var data, config *SSHKeyPairResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsNull() {
+		if r.defaults.DefaultType == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Missing key type",
+				"type must be set when the provider does not configure default_type.",
+			)
+
+			return
+		}
+
+		data.Type = types.StringValue(string(r.defaults.DefaultType))
+	}
+
+	if data.Type.IsUnknown() {
+		return
+	}
+
+	if config.Curve.IsNull() {
+		data.Curve = optionalString(string(r.defaults.defaultCurve(keygen.KeyType(data.Type.ValueString()))))
+	}
+
+	resp.Diagnostics.Append(validateKeyParameters(data.Type, data.Bits, data.Curve)...)
+	resp.Diagnostics.Append(validateSeedType(data.Type, data.Seed)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
 }
 
-// no need to support Read at the moment since the resource is fully within state
-// NOTE: if we support sourcing from files on disk in the future, this will have to be implemented.
+// Update re-encrypts the existing private key when the passphrase changes and
+// re-marshals it when the comment changes. Other changes keep the encodings in
+// state, except for the PuTTY encoding which follows putty_version. The key
+// material itself is never regenerated here.
 func (r *SSHKeyPairResource) Update(
-	_ context.Context,
-	_ resource.UpdateRequest,
-	_ *resource.UpdateResponse,
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
 ) {
+	var data, state *SSHKeyPairResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reencode := data.reencodes(state)
+	if !reencode && !data.PrivateKeyPuTTY.IsUnknown() {
+		tflog.Trace(ctx, "updated a resource")
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+		return
+	}
+
+	passphrase, diags := r.passphrase(ctx, req.Config, data)
+	resp.Diagnostics.Append(diags...)
+
+	previous, diags := r.previousPassphrase(ctx, req.Config, state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported keys are encrypted with the configured passphrase, which is
+	// not in state.
+	if previous == nil {
+		previous = passphrase
+	}
+
+	sshkey, err := keygen.Parse([]byte(state.PrivateKeyPEM.ValueString()), previous)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read existing private key", err.Error())
+
+		return
+	}
+
+	sshkey.Passphrase = passphrase
+	sshkey.KDFRounds = r.defaults.DefaultKDFRounds
+	sshkey.Comment = commentFromPublicKey(state.PublicKey.ValueString())
+
+	if !data.Comment.IsNull() {
+		sshkey.Comment = data.Comment.ValueString()
+	}
+
+	if reencode {
+		data.setPrivateKeys(sshkey)
+		data.PublicKey = types.StringValue(string(sshkey.PublicKey()))
+	} else {
+		data.PrivateKeyPuTTY = types.StringValue(string(sshkey.PrivateKeyPuTTY(keygen.PPKVersion(data.PuTTYVersion.ValueInt64()))))
+	}
+
+	tflog.Trace(ctx, "updated a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SSHKeyPairResource) Delete(
@@ -265,10 +840,255 @@ func (r *SSHKeyPairResource) Delete(
 	resp.State.RemoveResource(ctx)
 }
 
+// repairPublicKey recomputes the attributes derived from the public key and
+// returns the names of the attributes which did not match.
+func (m *SSHKeyPairResourceModel) repairPublicKey(pubKey *keygen.PublicKey) []string {
+	var repaired []string
+
+	repair := func(name string, attr *types.String, value string) {
+		if attr.ValueString() != value {
+			*attr = types.StringValue(value)
+			repaired = append(repaired, name)
+		}
+	}
+
+	repair("id", &m.ID, pubKey.SHA256())
+	repair("public_key", &m.PublicKey, string(pubKey.Marshal()))
+	repair("fingerprint_md5", &m.FingerprintMD5, pubKey.MD5())
+	repair("fingerprint_sha256", &m.FingerprintSHA256, pubKey.SHA256())
+
+	if jwk, err := pubKey.JWK(); err == nil {
+		repair("public_key_jwk", &m.PublicKeyJWK, string(jwk))
+	}
+
+	return repaired
+}
+
+// reencodes reports whether the private key has to be re-encoded, because its
+// passphrase or comment changes. The write-only passphrase changes with its
+// version.
+func (m *SSHKeyPairResourceModel) reencodes(state *SSHKeyPairResourceModel) bool {
+	return !m.Passphrase.Equal(state.Passphrase) ||
+		!m.PassphraseWOVersion.Equal(state.PassphraseWOVersion) ||
+		(!m.Comment.IsNull() && !m.Comment.Equal(state.Comment))
+}
+
+// hasRotation reports whether a rotation policy is configured.
+func (m *SSHKeyPairResourceModel) hasRotation() bool {
+	return !m.RotationDays.IsNull() || !m.RotationRFC3339.IsNull()
+}
+
+// expiresAt returns the expiry of the key according to the rotation policy.
+// Keys without creation time expire rotation_days after now.
+func (m *SSHKeyPairResourceModel) expiresAt(now time.Time) types.String {
+	switch {
+	case m.RotationDays.IsUnknown() || m.RotationRFC3339.IsUnknown() || m.CreatedAt.IsUnknown():
+		return types.StringUnknown()
+	case !m.RotationRFC3339.IsNull():
+		return m.RotationRFC3339
+	case !m.RotationDays.IsNull():
+		createdAt := now
+		if !m.CreatedAt.IsNull() {
+			if ts, err := time.Parse(time.RFC3339, m.CreatedAt.ValueString()); err == nil {
+				createdAt = ts
+			}
+		}
+
+		days := time.Duration(m.RotationDays.ValueInt64()) * 24 * time.Hour //nolint:mnd
+
+		return types.StringValue(createdAt.Add(days).UTC().Format(time.RFC3339))
+	default:
+		return types.StringNull()
+	}
+}
+
+// passphrase returns the passphrase the private key should be encrypted with.
+// The write-only attribute is only available in the configuration.
+func (r *SSHKeyPairResource) passphrase(
+	ctx context.Context,
+	config tfsdk.Config,
+	data *SSHKeyPairResourceModel,
+) ([]byte, diag.Diagnostics) {
+	var passphraseWO types.String
+
+	diags := config.GetAttribute(ctx, path.Root("passphrase_wo"), &passphraseWO)
+
+	switch {
+	case !passphraseWO.IsNull():
+		return []byte(passphraseWO.ValueString()), diags
+	case !data.Passphrase.IsNull():
+		return []byte(data.Passphrase.ValueString()), diags
+	default:
+		return nil, diags
+	}
+}
+
+// previousPassphrase returns the passphrase the private key in state is
+// currently encrypted with. Without an explicit previous passphrase the
+// current write-only passphrase is tried, as its version may have changed
+// without the value itself changing.
+func (r *SSHKeyPairResource) previousPassphrase(
+	ctx context.Context,
+	config tfsdk.Config,
+	state *SSHKeyPairResourceModel,
+) ([]byte, diag.Diagnostics) {
+	var previousWO, passphraseWO types.String
+
+	diags := config.GetAttribute(ctx, path.Root("previous_passphrase_wo"), &previousWO)
+	diags.Append(config.GetAttribute(ctx, path.Root("passphrase_wo"), &passphraseWO)...)
+
+	switch {
+	case !previousWO.IsNull():
+		return []byte(previousWO.ValueString()), diags
+	case !state.Passphrase.IsNull():
+		return []byte(state.Passphrase.ValueString()), diags
+	case !passphraseWO.IsNull():
+		return []byte(passphraseWO.ValueString()), diags
+	default:
+		return nil, diags
+	}
+}
+
+// validateStatePassphrase requires the configuration to provide the
+// passphrase of an encrypted private key in state, which is not stored along
+// with it, e.g. after importing an encrypted key.
+func (r *SSHKeyPairResource) validateStatePassphrase(
+	ctx context.Context,
+	config tfsdk.Config,
+	data *SSHKeyPairResourceModel,
+	state *SSHKeyPairResourceModel,
+) diag.Diagnostics {
+	var passphraseWO types.String
+
+	diags := config.GetAttribute(ctx, path.Root("passphrase_wo"), &passphraseWO)
+
+	if !state.Passphrase.IsNull() || !data.Passphrase.IsNull() || !passphraseWO.IsNull() {
+		return diags
+	}
+
+	_, err := keygen.Parse([]byte(state.PrivateKeyPEM.ValueString()), nil)
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		diags.AddAttributeError(
+			path.Root("passphrase"),
+			"Missing passphrase",
+			"The private key in state is encrypted. Set passphrase or passphrase_wo to the passphrase it is encrypted with.",
+		)
+	}
+
+	return diags
+}
+
+// sshkeySSHFPRecords returns the SSHFP records of a generated key pair.
+func sshkeySSHFPRecords(sshkey *keygen.SSHKeyPair, diags *diag.Diagnostics) types.List {
+	pubKey, err := keygen.ParseAuthorizedKey(sshkey.PublicKey())
+	if err != nil {
+		diags.AddError("Unable to parse public key", err.Error())
+
+		return types.ListNull(sshfpRecordType)
+	}
+
+	records, d := sshfpRecords(pubKey)
+	diags.Append(d...)
+
+	return records
+}
+
+// commentFromPublicKey extracts the comment of an authorized_keys line.
+func commentFromPublicKey(publicKey string) string {
+	_, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return ""
+	}
+
+	return comment
+}
+
+// ImportState imports an existing private key. The import identifier is
+// either the PEM encoded private key itself, "file:<path>" or "env:<name>".
+// The passphrase of encrypted keys is read from SSHKEY_IMPORT_PASSPHRASE. It
+// is only used to read the key and never stored in state; the configuration
+// has to provide it as passphrase or passphrase_wo.
 func (r *SSHKeyPairResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	pemBytes, err := importPrivateKey(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read private key", err.Error())
+
+		return
+	}
+
+	passphrase := os.Getenv(importPassphraseEnv)
+
+	sshkey, err := keygen.Parse(pemBytes, []byte(passphrase))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import private key", err.Error())
+
+		return
+	}
+
+	sshkey.KDFRounds = r.defaults.DefaultKDFRounds
+
+	data := SSHKeyPairResourceModel{
+		ID:                types.StringValue(sshkey.SHA256()),
+		Type:              types.StringValue(string(sshkey.Type)),
+		Bits:              types.Int64Value(int64(sshkey.Bits)),
+		Curve:             optionalString(string(sshkey.Curve)),
+		Comment:           types.StringNull(),
+		Keepers:           types.MapNull(types.StringType),
+		Seed:              types.StringNull(),
+		SeedLabel:         types.StringNull(),
+		PublicKey:         types.StringValue(string(sshkey.PublicKey())),
+		PublicKeyJWK:      types.StringValue(string(sshkey.PublicKeyJWK())),
+		FingerprintMD5:    types.StringValue(sshkey.MD5()),
+		FingerprintSHA256: types.StringValue(sshkey.SHA256()),
+		SSHFPRecords:      sshkeySSHFPRecords(sshkey, &resp.Diagnostics),
+		Passphrase:        types.StringNull(),
+		SSHKeyPairPrivateKeysModel: SSHKeyPairPrivateKeysModel{
+			PuTTYVersion: types.Int64Value(int64(keygen.PPKDefaultVersion)),
+		},
+		CreatedAt: types.StringNull(),
+		ExpiresAt: types.StringNull(),
+	}
+
+	data.setPrivateKeys(sshkey)
+
+	if sshkey.Comment != "" {
+		data.Comment = types.StringValue(sshkey.Comment)
+	}
+
+	// Keep encrypted OpenSSH keys as they are, the other encodings are
+	// encrypted with the same passphrase.
+	if encrypted := keygen.EncryptedOpenSSH(pemBytes); encrypted != nil {
+		data.PrivateKeyPEM = types.StringValue(string(encrypted))
+		data.PrivateKeyOpenSSH = data.PrivateKeyPEM
+	}
+
+	tflog.Trace(ctx, "imported a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// importPrivateKey resolves an import identifier to a PEM encoded private key.
+func importPrivateKey(id string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(id, "file:"):
+		//nolint:wrapcheck
+		return os.ReadFile(strings.TrimPrefix(id, "file:"))
+	case strings.HasPrefix(id, "env:"):
+		name := strings.TrimPrefix(id, "env:")
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errImportEnvNotSet, name)
+		}
+
+		return []byte(value), nil
+	default:
+		return []byte(id), nil
+	}
 }
@@ -0,0 +1,158 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeyPrivateKeyDataSource{}
+
+func NewSSHKeyPrivateKeyDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeyPrivateKeyDataSource{}
+}
+
+// SSHKeyPrivateKeyDataSource defines the data source implementation.
+type SSHKeyPrivateKeyDataSource struct{}
+
+// SSHKeyPrivateKeyDataSourceModel describes the data source data model.
+type SSHKeyPrivateKeyDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	PrivateKey        types.String `tfsdk:"private_key"`
+	Passphrase        types.String `tfsdk:"passphrase"`
+	Type              types.String `tfsdk:"type"`
+	Bits              types.Int64  `tfsdk:"bits"`
+	Curve             types.String `tfsdk:"curve"`
+	Comment           types.String `tfsdk:"comment"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (d *SSHKeyPrivateKeyDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_private_key"
+}
+
+//
+//nolint:funlen
+func (d *SSHKeyPrivateKeyDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Derives the public key and fingerprints from an existing private key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 fingerprint of the public key",
+			},
+			"private_key": schema.StringAttribute{
+				Description:         "Private key in PEM format",
+				MarkdownDescription: "Private key in OpenSSH, PKCS#1, PKCS#8 or SEC1 PEM format",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"passphrase": schema.StringAttribute{
+				Description:         "Passphrase to decrypt the private key",
+				MarkdownDescription: "Passphrase to decrypt the private key, if it is encrypted",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"type": schema.StringAttribute{
+				Description:         "SSH key type",
+				MarkdownDescription: "SSH key type as used by `sshkey_pair`, i.e. `rsa`, `ed25519` or `ecdsa`",
+				Computed:            true,
+			},
+			"bits": schema.Int64Attribute{
+				Description:         "Size of the key in bits",
+				MarkdownDescription: "Size of the key in bits",
+				Computed:            true,
+			},
+			"curve": schema.StringAttribute{
+				Description:         "ECDSA curve",
+				MarkdownDescription: "NIST curve of `ecdsa` keys, e.g. `P256`",
+				Computed:            true,
+			},
+			"comment": schema.StringAttribute{
+				Description:         "SSH key comment",
+				MarkdownDescription: "SSH key comment, only available for keys in OpenSSH format",
+				Computed:            true,
+			},
+			"public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key",
+				MarkdownDescription: "OpenSSH public key, ready to be used in an `authorized_keys` file",
+				Computed:            true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				Description:         "OpenSSH key md5 fingerprint",
+				MarkdownDescription: "OpenSSH key md5 fingerprint",
+				Computed:            true,
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Description:         "OpenSSH key sha256 fingerprint",
+				MarkdownDescription: "OpenSSH key sha256 fingerprint",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeyPrivateKeyDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeyPrivateKeyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skeys, err := keygen.Parse([]byte(data.PrivateKey.ValueString()), []byte(data.Passphrase.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to parse private key", err.Error())
+
+		return
+	}
+
+	data.ID = types.StringValue(skeys.SHA256())
+	data.Type = types.StringValue(string(skeys.Type))
+	data.Bits = types.Int64Value(int64(skeys.Bits))
+	data.Curve = optionalString(string(skeys.Curve))
+	data.Comment = optionalString(skeys.Comment)
+	data.PublicKey = types.StringValue(string(skeys.PublicKey()))
+	data.FingerprintMD5 = types.StringValue(skeys.MD5())
+	data.FingerprintSHA256 = types.StringValue(skeys.SHA256())
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSSHKeyPrivateKeyDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "ecdsa"
+  curve      = "P256"
+  comment    = "test@example.com"
+  passphrase = "secret"
+}
+
+data "sshkey_private_key" "test" {
+  private_key = sshkey_pair.test.private_key
+  passphrase  = "secret"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sshkey_private_key.test", "type", "ecdsa"),
+					resource.TestCheckResourceAttr("data.sshkey_private_key.test", "bits", "256"),
+					resource.TestCheckResourceAttr("data.sshkey_private_key.test", "curve", "P256"),
+					resource.TestCheckResourceAttr("data.sshkey_private_key.test", "comment", "test@example.com"),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_private_key.test", "public_key",
+						"sshkey_pair.test", "public_key",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_private_key.test", "fingerprint_sha256",
+						"sshkey_pair.test", "fingerprint_sha256",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_private_key.test", "fingerprint_md5",
+						"sshkey_pair.test", "fingerprint_md5",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyPrivateKeyDataSourceWrongPassphrase(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type       = "ed25519"
+  passphrase = "secret"
+}
+
+data "sshkey_private_key" "test" {
+  private_key = sshkey_pair.test.private_key
+  passphrase  = "wrong"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse private key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeyPublicKeyDataSource{}
+
+func NewSSHKeyPublicKeyDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeyPublicKeyDataSource{}
+}
+
+// SSHKeyPublicKeyDataSource defines the data source implementation.
+type SSHKeyPublicKeyDataSource struct{}
+
+// SSHKeyPublicKeyDataSourceModel describes the data source data model.
+type SSHKeyPublicKeyDataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	PublicKey           types.String `tfsdk:"public_key"`
+	Algorithm           types.String `tfsdk:"algorithm"`
+	Type                types.String `tfsdk:"type"`
+	Bits                types.Int64  `tfsdk:"bits"`
+	Curve               types.String `tfsdk:"curve"`
+	SecurityKey         types.Bool   `tfsdk:"security_key"`
+	Comment             types.String `tfsdk:"comment"`
+	Options             types.List   `tfsdk:"options"`
+	NormalizedPublicKey types.String `tfsdk:"normalized_public_key"`
+	FingerprintMD5      types.String `tfsdk:"fingerprint_md5"`
+	FingerprintSHA256   types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (d *SSHKeyPublicKeyDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_public_key"
+}
+
+//
+//nolint:funlen
+func (d *SSHKeyPublicKeyDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Parses an OpenSSH public key in `authorized_keys` format",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 fingerprint of the public key",
+			},
+			"public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key",
+				MarkdownDescription: "OpenSSH public key in `authorized_keys` format, optionally prefixed with options",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				Description:         "SSH key algorithm",
+				MarkdownDescription: "SSH key algorithm, e.g. `ssh-ed25519`",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				Description: "SSH key type",
+				MarkdownDescription: "SSH key type, i.e. `rsa`, `ed25519` or `ecdsa` as used by `sshkey_pair`, " +
+					"or `ed25519-sk` and `ecdsa-sk` for FIDO security keys",
+				Computed: true,
+			},
+			"bits": schema.Int64Attribute{
+				Description:         "Size of the key in bits",
+				MarkdownDescription: "Size of the key in bits",
+				Computed:            true,
+			},
+			"curve": schema.StringAttribute{
+				Description:         "ECDSA curve",
+				MarkdownDescription: "NIST curve of `ecdsa` keys, e.g. `P256`",
+				Computed:            true,
+			},
+			"security_key": schema.BoolAttribute{
+				Description:         "Whether the key belongs to a FIDO security key",
+				MarkdownDescription: "Whether the key belongs to a FIDO security key, e.g. `sk-ssh-ed25519@openssh.com`",
+				Computed:            true,
+			},
+			"comment": schema.StringAttribute{
+				Description:         "SSH key comment",
+				MarkdownDescription: "SSH key comment",
+				Computed:            true,
+			},
+			"options": schema.ListAttribute{
+				Description:         "authorized_keys options",
+				MarkdownDescription: "`authorized_keys` options preceding the key, e.g. `no-pty`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"normalized_public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key without options",
+				MarkdownDescription: "OpenSSH public key without options",
+				Computed:            true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				Description:         "OpenSSH key md5 fingerprint",
+				MarkdownDescription: "OpenSSH key md5 fingerprint",
+				Computed:            true,
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Description:         "OpenSSH key sha256 fingerprint",
+				MarkdownDescription: "OpenSSH key sha256 fingerprint",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeyPublicKeyDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeyPublicKeyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pubKey, err := keygen.ParseAuthorizedKey([]byte(data.PublicKey.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to parse public key", err.Error())
+
+		return
+	}
+
+	options, diags := types.ListValueFrom(ctx, types.StringType, pubKey.Options)
+	resp.Diagnostics.Append(diags...)
+
+	data.ID = types.StringValue(pubKey.SHA256())
+	data.Algorithm = types.StringValue(pubKey.Algorithm())
+	data.Type = optionalString(string(pubKey.Type()))
+	data.Bits = types.Int64Value(int64(pubKey.Bits()))
+	data.Curve = optionalString(string(pubKey.Curve()))
+	data.SecurityKey = types.BoolValue(pubKey.SecurityKey())
+	data.Comment = types.StringValue(pubKey.Comment)
+	data.Options = options
+	data.NormalizedPublicKey = types.StringValue(string(pubKey.Marshal()))
+	data.FingerprintMD5 = types.StringValue(pubKey.MD5())
+	data.FingerprintSHA256 = types.StringValue(pubKey.SHA256())
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalString returns a null string for empty values.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSSHKeyPublicKeyDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "test" {
+  type  = "ecdsa"
+  curve = "P521"
+}
+
+data "sshkey_public_key" "test" {
+  public_key = "no-pty,from=\"10.0.0.0/8\" ${sshkey_pair.test.public_key}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "algorithm", "ecdsa-sha2-nistp521"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "type", "ecdsa"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "bits", "521"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "curve", "P521"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "security_key", "false"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "options.#", "2"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "options.0", "no-pty"),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_public_key.test", "normalized_public_key",
+						"sshkey_pair.test", "public_key",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_public_key.test", "fingerprint_sha256",
+						"sshkey_pair.test", "fingerprint_sha256",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.sshkey_public_key.test", "fingerprint_md5",
+						"sshkey_pair.test", "fingerprint_md5",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyPublicKeyDataSourceSecurityKey(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sshkey_public_key" "test" {
+  public_key = join(" ", [
+    "sk-ecdsa-sha2-nistp256@openssh.com",
+    join("", [
+      "AAAAInNrLWVjZHNhLXNoYTItbmlzdHAyNTZAb3BlbnNzaC5jb20AAAAIbmlzdHAyNTYAAABBBKWjNSsrb/p2So8F1xT5Sc8am+0HXYOp",
+      "lFp6i26b1Su5jpVwN2J9oV7MMD69ddfp/W5ojT+pizlFQYT2WV4sFh0AAAAEc3NoOg==",
+    ]),
+    "jane@example.com",
+  ])
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "algorithm", "sk-ecdsa-sha2-nistp256@openssh.com"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "type", "ecdsa-sk"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "bits", "256"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "curve", "P256"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "security_key", "true"),
+					resource.TestCheckResourceAttr("data.sshkey_public_key.test", "comment", "jane@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyPublicKeyDataSourceInvalid(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sshkey_public_key" "test" {
+  public_key = "ssh-ed25519 invalid"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse public key`),
+			},
+		},
+	})
+}
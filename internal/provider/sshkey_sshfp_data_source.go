@@ -0,0 +1,195 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SSHKeySSHFPDataSource{}
+
+func NewSSHKeySSHFPDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHKeySSHFPDataSource{}
+}
+
+// SSHKeySSHFPDataSource defines the data source implementation.
+type SSHKeySSHFPDataSource struct{}
+
+// SSHKeySSHFPDataSourceModel describes the data source data model.
+type SSHKeySSHFPDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	PublicKeys []string     `tfsdk:"public_keys"`
+	Hostname   types.String `tfsdk:"hostname"`
+	Records    types.List   `tfsdk:"records"`
+	Zone       types.String `tfsdk:"zone"`
+}
+
+func (d *SSHKeySSHFPDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sshfp"
+}
+
+//
+//nolint:funlen
+func (d *SSHKeySSHFPDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates SSHFP DNS records (RFC 4255) of host public keys, like `ssh-keygen -r`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 checksum of the SSHFP records",
+			},
+			"public_keys": schema.ListAttribute{
+				Description:         "OpenSSH host public keys",
+				MarkdownDescription: "OpenSSH host public keys in `authorized_keys` format, e.g. `sshkey_pair.host.public_key`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description:         "Owner name of the records in zone",
+				MarkdownDescription: "Owner name of the records in `zone`, e.g. `host.example.com.`",
+				Optional:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "SSHFP records of the public keys",
+				MarkdownDescription: "SSHFP records of the public keys with SHA-1 and SHA-256 fingerprints, " +
+					"in the order of `public_keys`",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"algorithm": schema.Int64Attribute{
+							MarkdownDescription: sshfpRecordDescriptions["algorithm"],
+							Computed:            true,
+						},
+						"fingerprint_type": schema.Int64Attribute{
+							MarkdownDescription: sshfpRecordDescriptions["fingerprint_type"],
+							Computed:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: sshfpRecordDescriptions["fingerprint"],
+							Computed:            true,
+						},
+						"rdata": schema.StringAttribute{
+							MarkdownDescription: sshfpRecordDescriptions["rdata"],
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "SSHFP records in zone file format",
+				MarkdownDescription: "SSHFP records in zone file format, e.g. `host.example.com. IN SSHFP 4 2 <fingerprint>`. " +
+					"Only set together with `hostname`.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *SSHKeySSHFPDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data *SSHKeySSHFPDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		records []attr.Value
+		rdata   strings.Builder
+		zone    strings.Builder
+	)
+
+	for i, publicKey := range data.PublicKeys {
+		keyPath := path.Root("public_keys").AtListIndex(i)
+
+		pubKey, err := keygen.ParseAuthorizedKey([]byte(publicKey))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(keyPath, "Unable to parse public key", err.Error())
+
+			continue
+		}
+
+		list, diags := sshfpRecords(pubKey)
+		for _, diagnostic := range diags {
+			resp.Diagnostics.AddAttributeError(keyPath, diagnostic.Summary(), diagnostic.Detail())
+		}
+
+		records = append(records, list.Elements()...)
+
+		sshfp, err := pubKey.SSHFP()
+		if err != nil {
+			continue
+		}
+
+		for _, record := range sshfp {
+			fmt.Fprintf(&rdata, "%s\n", record)
+			fmt.Fprintf(&zone, "%s IN SSHFP %s\n", data.Hostname.ValueString(), record)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := types.ListValue(sshfpRecordType, records)
+	resp.Diagnostics.Append(diags...)
+
+	checksum := sha256.Sum256([]byte(rdata.String()))
+
+	data.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	data.Records = list
+	data.Zone = types.StringNull()
+
+	if !data.Hostname.IsNull() {
+		data.Zone = types.StringValue(zone.String())
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSSHKeySSHFPDataSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "host" {
+  type = "rsa"
+}
+
+data "sshkey_sshfp" "test" {
+  hostname = "web.example.com."
+  public_keys = [
+    "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIIgN1k3iMiRGj0FJdwxZD457aoB02bpl3nBGsMD9N2fQ host",
+    sshkey_pair.host.public_key,
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sshkey_sshfp.test", "records.#", "4"),
+					resource.TestCheckResourceAttr("data.sshkey_sshfp.test", "records.0.algorithm", "4"),
+					resource.TestCheckResourceAttr("data.sshkey_sshfp.test", "records.0.fingerprint_type", "1"),
+					resource.TestCheckResourceAttr(
+						"data.sshkey_sshfp.test", "records.1.rdata",
+						"4 2 ee6fd3b1c5b34132960859d3b2445dc0c8fff85ecaa31d4ef58fd90831815a95",
+					),
+					resource.TestMatchResourceAttr(
+						"data.sshkey_sshfp.test", "records.3.rdata", regexp.MustCompile(`^1 2 [0-9a-f]{64}$`),
+					),
+					resource.TestMatchResourceAttr(
+						"data.sshkey_sshfp.test", "zone",
+						regexp.MustCompile(`^web\.example\.com\. IN SSHFP 4 1 cbcea0252e78db13dcdb077f20571ed5c5715d4d\n`+
+							`web\.example\.com\. IN SSHFP 4 2 ee6fd3b1c5b34132960859d3b2445dc0c8fff85ecaa31d4ef58fd90831815a95\n`+
+							`web\.example\.com\. IN SSHFP 1 1 [0-9a-f]{40}\n`+
+							`web\.example\.com\. IN SSHFP 1 2 [0-9a-f]{64}\n$`),
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeySSHFPDataSourceSecurityKey(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "sshkey_sshfp" "test" {
+  public_keys = [
+    join(" ", [
+      "sk-ssh-ed25519@openssh.com",
+      "AAAAGnNrLXNzaC1lZDI1NTE5QG9wZW5zc2guY29tAAAAIDWzoLKY67AUyM60gIf+mSwtrqVLvZMHpjJjhuKOVArVAAAABHNzaDo=",
+      "jane@example.com",
+    ]),
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to create SSHFP records`),
+			},
+		},
+	})
+}
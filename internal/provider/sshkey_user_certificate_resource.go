@@ -0,0 +1,288 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SSHKeyUserCertificateResource{}
+
+func NewSSHKeyUserCertificateResource() resource.Resource { //nolint:ireturn
+	return &SSHKeyUserCertificateResource{}
+}
+
+// SSHKeyUserCertificateResource defines the resource implementation.
+type SSHKeyUserCertificateResource struct{}
+
+// SSHKeyUserCertificateResourceModel describes the resource data model.
+type SSHKeyUserCertificateResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	CAPrivateKey           types.String `tfsdk:"ca_private_key"`
+	CAPrivateKeyPassphrase types.String `tfsdk:"ca_private_key_passphrase"`
+	PublicKey              types.String `tfsdk:"public_key"`
+	KeyID                  types.String `tfsdk:"key_id"`
+	Serial                 types.Int64  `tfsdk:"serial"`
+	Principals             types.List   `tfsdk:"principals"`
+	ValidAfter             types.String `tfsdk:"valid_after"`
+	ValidBefore            types.String `tfsdk:"valid_before"`
+	CriticalOptions        types.Map    `tfsdk:"critical_options"`
+	Extensions             types.Set    `tfsdk:"extensions"`
+	Certificate            types.String `tfsdk:"certificate"`
+	CAPublicKey            types.String `tfsdk:"ca_public_key"`
+}
+
+func (r *SSHKeyUserCertificateResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_user_certificate"
+}
+
+//
+//nolint:funlen
+func (r *SSHKeyUserCertificateResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	defaultExtensions := make([]attr.Value, 0, len(keygen.SSHCertDefaultExtensions))
+	for _, ext := range keygen.SSHCertDefaultExtensions {
+		defaultExtensions = append(defaultExtensions, types.StringValue(ext))
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OpenSSH user certificate signed by a certificate authority key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 fingerprint of the certificate",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_private_key": schema.StringAttribute{
+				Description:         "Private key of the certificate authority",
+				MarkdownDescription: "Private key of the certificate authority, e.g. `sshkey_pair.ca.private_key`",
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_private_key_passphrase": schema.StringAttribute{
+				Description:         "Passphrase of the certificate authority private key",
+				MarkdownDescription: "Passphrase of the certificate authority private key",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key to sign",
+				MarkdownDescription: "OpenSSH public key to sign, in `authorized_keys` format. FIDO security keys are supported.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				Description:         "Certificate key identifier",
+				MarkdownDescription: "Certificate key identifier, logged by the server when the certificate is used",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"serial": schema.Int64Attribute{
+				Description:         "Certificate serial number",
+				MarkdownDescription: "Certificate serial number (default: `0`)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.ListAttribute{
+				Description:         "User names the certificate is valid for",
+				MarkdownDescription: "User names the certificate is valid for. An empty list allows any user.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"valid_after": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp the certificate is valid from",
+				MarkdownDescription: "RFC 3339 timestamp the certificate is valid from (default: always)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"valid_before": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp the certificate is valid until",
+				MarkdownDescription: "RFC 3339 timestamp the certificate is valid until (default: forever)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"critical_options": schema.MapAttribute{
+				Description: "Certificate critical options",
+				MarkdownDescription: "Certificate critical options. " +
+					"Supported options are `force-command`, `source-address` and `verify-required`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(keygen.SSHCertCriticalOptions...)),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"extensions": schema.SetAttribute{
+				Description: "Certificate extensions",
+				MarkdownDescription: "Certificate extensions, e.g. `permit-pty` or `permit-port-forwarding` " +
+					"(default: the `ssh-keygen` defaults)",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, defaultExtensions)),
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Description:         "OpenSSH certificate",
+				MarkdownDescription: "OpenSSH certificate, ready to be stored as `<key>-cert.pub`",
+				Computed:            true,
+			},
+			"ca_public_key": schema.StringAttribute{
+				Description:         "OpenSSH public key of the certificate authority",
+				MarkdownDescription: "OpenSSH public key of the certificate authority, for use in `TrustedUserCAKeys`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SSHKeyUserCertificateResource) Configure(
+	_ context.Context,
+	_ resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+}
+
+func (r *SSHKeyUserCertificateResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data *SSHKeyUserCertificateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conf := keygen.CertificateConfig{
+		CertType:  ssh.UserCert,
+		PublicKey: []byte(data.PublicKey.ValueString()),
+		KeyID:     data.KeyID.ValueString(),
+		Serial:    uint64(data.Serial.ValueInt64()), //nolint:gosec
+	}
+
+	resp.Diagnostics.Append(data.Principals.ElementsAs(ctx, &conf.Principals, false)...)
+	resp.Diagnostics.Append(data.CriticalOptions.ElementsAs(ctx, &conf.CriticalOptions, false)...)
+
+	conf.Extensions = extensionsMap(ctx, data.Extensions, &resp.Diagnostics)
+	conf.ValidAfter = parseTimestamp(data.ValidAfter, &resp.Diagnostics)
+	conf.ValidBefore = parseTimestamp(data.ValidBefore, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert := signCertificate(data.CAPrivateKey, data.CAPrivateKeyPassphrase, &conf, &resp.Diagnostics)
+	if cert == nil {
+		return
+	}
+
+	data.ID = types.StringValue(ssh.FingerprintSHA256(cert.Cert))
+	data.Certificate = types.StringValue(string(cert.Marshal()))
+	data.CAPublicKey = types.StringValue(caPublicKey(cert))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// no need to support Read at the moment since the resource is fully within state.
+func (r *SSHKeyUserCertificateResource) Read(
+	_ context.Context,
+	_ resource.ReadRequest,
+	_ *resource.ReadResponse,
+) {
+}
+
+// all attributes require replacement, so Update is never called.
+func (r *SSHKeyUserCertificateResource) Update(
+	_ context.Context,
+	_ resource.UpdateRequest,
+	_ *resource.UpdateResponse,
+) {
+}
+
+func (r *SSHKeyUserCertificateResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	resp.State.RemoveResource(ctx)
+}
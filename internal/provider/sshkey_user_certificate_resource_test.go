@@ -0,0 +1,164 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAccSSHKeyUserCertificateResource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_pair" "user" {
+  type = "ecdsa"
+}
+
+resource "sshkey_user_certificate" "test" {
+  ca_private_key = sshkey_pair.ca.private_key
+  public_key     = sshkey_pair.user.public_key
+  key_id         = "user@example.com"
+  serial         = 7
+  principals     = ["root", "deploy"]
+  valid_after    = "2025-01-01T00:00:00Z"
+  valid_before   = "2035-01-01T00:00:00Z"
+
+  critical_options = {
+    "source-address" = "10.0.0.0/8"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"sshkey_user_certificate.test",
+						"certificate",
+						regexp.MustCompile(`^ecdsa-sha2-nistp384-cert-v01@openssh.com `),
+					),
+					resource.TestMatchResourceAttr(
+						"sshkey_user_certificate.test",
+						"ca_public_key",
+						regexp.MustCompile(`^ssh-ed25519 \S+$`),
+					),
+					resource.TestCheckResourceAttr("sshkey_user_certificate.test", "extensions.#", "5"),
+					resource.TestCheckResourceAttrWith("sshkey_user_certificate.test", "certificate", testCheckUserCertificate),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyUserCertificateResourceSecurityKey(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_user_certificate" "test" {
+  ca_private_key = sshkey_pair.ca.private_key
+  public_key = join(" ", [
+    "sk-ssh-ed25519@openssh.com",
+    "AAAAGnNrLXNzaC1lZDI1NTE5QG9wZW5zc2guY29tAAAAIDWzoLKY67AUyM60gIf+mSwtrqVLvZMHpjJjhuKOVArVAAAABHNzaDo=",
+    "jane@example.com",
+  ])
+  principals = ["jane"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"sshkey_user_certificate.test",
+						"certificate",
+						regexp.MustCompile(`^sk-ssh-ed25519-cert-v01@openssh.com \S+ jane@example.com$`),
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyUserCertificateResourceInvalidCriticalOption(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "sshkey_pair" "ca" {
+  type = "ed25519"
+}
+
+resource "sshkey_user_certificate" "test" {
+  ca_private_key = sshkey_pair.ca.private_key
+  public_key     = sshkey_pair.ca.public_key
+
+  critical_options = {
+    "no-such-option" = ""
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func testCheckUserCertificate(value string) error {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(value))
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate: %w", err)
+	}
+
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return fmt.Errorf("expected a certificate, got %T", pub)
+	}
+
+	switch {
+	case cert.CertType != ssh.UserCert:
+		return fmt.Errorf("unexpected certificate type %d", cert.CertType)
+	case cert.KeyId != "user@example.com" || cert.Serial != 7:
+		return fmt.Errorf("unexpected key id %q or serial %d", cert.KeyId, cert.Serial)
+	case !slices.Equal(cert.ValidPrincipals, []string{"root", "deploy"}):
+		return fmt.Errorf("unexpected principals %v", cert.ValidPrincipals)
+	case cert.CriticalOptions["source-address"] != "10.0.0.0/8":
+		return fmt.Errorf("unexpected critical options %v", cert.CriticalOptions)
+	}
+
+	return nil
+}
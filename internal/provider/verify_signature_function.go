@@ -0,0 +1,143 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jlec/terraform-provider-sshkey/internal/keygen"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VerifySignatureFunction{}
+
+func NewVerifySignatureFunction() function.Function { //nolint:ireturn
+	return &VerifySignatureFunction{}
+}
+
+// VerifySignatureFunction defines the function implementation.
+type VerifySignatureFunction struct{}
+
+func (f *VerifySignatureFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "verify_signature"
+}
+
+func (f *VerifySignatureFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Verify a signature like ssh-keygen -Y verify",
+		MarkdownDescription: "Verifies an armored `SSH SIGNATURE` of a message like `ssh-keygen -Y verify` and returns " +
+			"whether it was made within the namespace by a key allowed to sign as the principal at the given time. " +
+			"`cert-authority` entries of `allowed_signers` are rejected, since signatures made with certificates " +
+			"are not supported",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "allowed_signers",
+				MarkdownDescription: "Trusted keys in the `allowed_signers` format of `ssh-keygen`",
+			},
+			function.StringParameter{
+				Name:                "principal",
+				MarkdownDescription: "Identity of the signer like `user@example.com`",
+			},
+			function.StringParameter{
+				Name:                "namespace",
+				MarkdownDescription: "Namespace the signature has to be made within",
+			},
+			function.StringParameter{
+				Name:                "message",
+				MarkdownDescription: "Signed message",
+			},
+			function.StringParameter{
+				Name:                "signature",
+				MarkdownDescription: "Armored `SSH SIGNATURE` as created by `ssh-keygen -Y sign`",
+			},
+			function.StringParameter{
+				Name: "at",
+				MarkdownDescription: "RFC 3339 timestamp checked against the `valid-after` and `valid-before` options " +
+					"of `allowed_signers`; `null` for the current time",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *VerifySignatureFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var (
+		allowedSigners string
+		principal      string
+		namespace      string
+		message        string
+		signature      string
+		at             types.String
+	)
+
+	resp.Error = function.ConcatFuncErrors(
+		req.Arguments.Get(ctx, &allowedSigners, &principal, &namespace, &message, &signature, &at),
+	)
+
+	if resp.Error != nil {
+		return
+	}
+
+	signers, err := keygen.ParseAllowedSigners([]byte(allowedSigners))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse allowed signers: "+err.Error())
+
+		return
+	}
+
+	sig, err := keygen.ParseSignature([]byte(signature))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(4, "Unable to parse signature: "+err.Error())
+
+		return
+	}
+
+	verifyTime := time.Now()
+
+	if !at.IsNull() {
+		if verifyTime, err = time.Parse(time.RFC3339, at.ValueString()); err != nil {
+			resp.Error = function.NewArgumentFuncError(5, "Invalid RFC 3339 timestamp: "+err.Error())
+
+			return
+		}
+	}
+
+	err = sig.VerifyAllowedSigners(signers, principal, namespace, []byte(message), verifyTime)
+	if err != nil && !errors.Is(err, keygen.ErrSignatureMismatch) {
+		resp.Error = function.NewArgumentFuncError(4, "Unable to verify signature: "+err.Error())
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, err == nil))
+}
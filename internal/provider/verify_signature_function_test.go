@@ -0,0 +1,135 @@
+/*
+Copyright 2022-2025 Justin Lecher
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// Signature of "hello world\n" in the namespace file as created by
+// ssh-keygen -Y sign.
+const testAccVerifySignatureConfig = `
+locals {
+  public_key      = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPtzYsiU9ok+kqKSKeTxblsE436a0FYbq3byigdnRtH8"
+  allowed_signers = <<-EOT
+    *@example.com namespaces="file",valid-after="20200101Z",valid-before="29990101Z" ${local.public_key}
+  EOT
+  signature = <<-EOT
+    -----BEGIN SSH SIGNATURE-----
+    U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAg+3NiyJT2iT6SopIp5PFuWwTjfp
+    rQVhurdvKKB2dG0fwAAAAEZmlsZQAAAAAAAAAGc2hhNTEyAAAAUwAAAAtzc2gtZWQyNTUx
+    OQAAAECRFPBo3+ikT2PdxVtbq1nsQVOm0dQcUL3GXzr+0L4gpPiIsb6sHjzmNkvPVCHi/f
+    +lXVbxR3r0VvY/5oK6Z2YM
+    -----END SSH SIGNATURE-----
+  EOT
+}
+`
+
+func TestAccVerifySignatureFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVerifySignatureConfig + `
+output "valid" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world\n", local.signature, null
+  )
+}
+
+output "principal" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.org", "file", "hello world\n", local.signature, null
+  )
+}
+
+output "namespace" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "git", "hello world\n", local.signature, null
+  )
+}
+
+output "message" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello mars\n", local.signature, null
+  )
+}
+
+output "not_yet_valid" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world\n", local.signature, "2019-12-31T23:59:59Z"
+  )
+}
+
+output "expired" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world\n", local.signature, "2999-01-01T00:00:01Z"
+  )
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("valid", "true"),
+					resource.TestCheckOutput("principal", "false"),
+					resource.TestCheckOutput("namespace", "false"),
+					resource.TestCheckOutput("message", "false"),
+					resource.TestCheckOutput("not_yet_valid", "false"),
+					resource.TestCheckOutput("expired", "false"),
+				),
+			},
+			{
+				Config: testAccVerifySignatureConfig + `
+output "test" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world\n", "invalid", null
+  )
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable\s+to\s+parse\s+signature`),
+			},
+			{
+				Config: testAccVerifySignatureConfig + `
+output "test" {
+  value = provider::sshkey::verify_signature(
+    "*@example.com cert-authority ${local.public_key}",
+    "test@example.com", "file", "hello world\n", local.signature, null
+  )
+}
+`,
+				ExpectError: regexp.MustCompile(`cert-authority\s+is\s+not\s+supported`),
+			},
+			{
+				Config: testAccVerifySignatureConfig + `
+output "test" {
+  value = provider::sshkey::verify_signature(
+    local.allowed_signers, "test@example.com", "file", "hello world\n", local.signature, "2025-01-01"
+  )
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+RFC\s+3339\s+timestamp`),
+			},
+		},
+	})
+}